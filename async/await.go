@@ -1,67 +1,329 @@
 package async
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"time"
 )
 
+// ErrNoFutures is returned when a combinator requires at least one future.
+var ErrNoFutures = errors.New("async: no futures")
+
+// Opts are the options for a future.
+type Opts struct {
+	// Executor is running the function of the future, a goroutine is started if nil.
+	Executor Executor
+}
+
+// Opt is a function that configures the options of a future.
+type Opt func(*Opts)
+
+// Configure is a method that configures the options of a future.
+func (o *Opts) Configure(opts ...Opt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// WithExecutor is running the function of the future on the executor.
+// The future rejects with the error of the executor if the function is rejected.
+func WithExecutor(executor Executor) Opt {
+	return func(opts *Opts) {
+		opts.Executor = executor
+	}
+}
+
 // New returns a new future.
-func New[T any](fn func() (T, error)) *Future[T] {
-	future := Future[T]{value: make(chan T)}
+func New[T any](fn func() (T, error), opts ...Opt) *Future[T] {
+	return NewWithContext(context.Background(), func(context.Context) (T, error) {
+		return fn()
+	}, opts...)
+}
 
-	go func() { // todo: use a sync.Pool for the goroutine
-		value, err := fn()
-		future.err = err
-		future.value <- value
+// NewWithContext returns a new future running the function with the context.
+// The future rejects with the error of the context when it is done before the function returns,
+// the context passed to the function is canceled when the future resolves or is canceled.
+func NewWithContext[T any](ctx context.Context, fn func(context.Context) (T, error), opts ...Opt) *Future[T] {
+	options := new(Opts)
+	options.Configure(opts...)
 
-		close(future.value)
+	ctx, cancel := context.WithCancel(ctx)
 
-		if future.onthen != nil {
-			future.onthen(value)
+	future := newFuture[T]()
+	future.cancel = cancel
+
+	stop := context.AfterFunc(ctx, func() {
+		var zero T
+		future.settle(zero, ctx.Err())
+	})
+
+	run := func() {
+		value, err := fn(ctx)
+		stop()
+
+		future.settle(value, err)
+	}
+
+	if options.Executor == nil {
+		go run()
+		return future
+	}
+
+	if err := options.Executor.Go(run); err != nil {
+		stop()
+
+		var zero T
+		future.settle(zero, err)
+	}
+
+	return future
+}
+
+// WithTimeout returns a future that resolves with the future,
+// or rejects with context.DeadlineExceeded and cancels the future when it does not resolve within the timeout.
+func WithTimeout[T any](f *Future[T], timeout time.Duration) *Future[T] {
+	return NewWithContext(context.Background(), func(ctx context.Context) (T, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		value, err := f.AwaitContext(ctx)
+		if ctx.Err() != nil {
+			f.Cancel()
 		}
 
-		if future.oncatch != nil {
-			future.oncatch(err)
+		return value, err
+	})
+}
+
+// Result is the value or the error of a settled future.
+type Result[T any] struct {
+	// Value is the value of the future.
+	Value T
+	// Err is the error of the future.
+	Err error
+}
+
+type indexed[T any] struct {
+	index int
+	Result[T]
+}
+
+// collect awaits the futures concurrently and calls fn in the order they settle,
+// until fn returns false or the context is done. The futures not settled by then are canceled.
+func collect[T any](ctx context.Context, futures []*Future[T], fn func(i int, r Result[T]) bool) error {
+	results := make(chan indexed[T], len(futures))
+
+	for i, f := range futures {
+		f.onSettle(func() {
+			results <- indexed[T]{index: i, Result: Result[T]{Value: f.value, Err: f.err}}
+		})
+	}
+
+	settled := make([]bool, len(futures))
+
+	defer func() {
+		for i, f := range futures {
+			if !settled[i] {
+				f.Cancel()
+			}
 		}
 	}()
 
-	return &future
+	for range futures {
+		select {
+		case r := <-results:
+			settled[r.index] = true
+
+			if !fn(r.index, r.Result) {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
 }
 
-// All returns a future that resolves when all the provided futures resolve.
+// All returns a future that resolves with the values of all the futures,
+// or rejects with the first error and cancels the futures not settled yet.
+// The futures are canceled as well when the returned future is canceled,
+// so futures shared with other consumers should not be passed.
 func All[T any](futures ...*Future[T]) *Future[[]T] {
-	return New(func() ([]T, error) {
+	return NewWithContext(context.Background(), func(ctx context.Context) ([]T, error) {
+		values := make([]T, len(futures))
+
+		var err error
+
+		if cerr := collect(ctx, futures, func(i int, r Result[T]) bool {
+			values[i], err = r.Value, r.Err
+			return err == nil
+		}); cerr != nil {
+			return nil, cerr
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return values, nil
+	})
+}
+
+// AllSettled returns a future that resolves with the results of all the futures
+// once they are settled, it never rejects unless it is canceled.
+// The futures not settled yet are canceled when the returned future is canceled.
+func AllSettled[T any](futures ...*Future[T]) *Future[[]Result[T]] {
+	return NewWithContext(context.Background(), func(ctx context.Context) ([]Result[T], error) {
+		results := make([]Result[T], len(futures))
+
+		if err := collect(ctx, futures, func(i int, r Result[T]) bool {
+			results[i] = r
+			return true
+		}); err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	})
+}
+
+// Race returns a future that settles with the first of the futures to settle,
+// the futures not settled yet are canceled, so futures shared with other consumers should not be passed.
+func Race[T any](futures ...*Future[T]) *Future[T] {
+	return NewWithContext(context.Background(), func(ctx context.Context) (T, error) {
+		if len(futures) == 0 {
+			var zero T
+			return zero, ErrNoFutures
+		}
+
+		var first Result[T]
+
+		if err := collect(ctx, futures, func(_ int, r Result[T]) bool {
+			first = r
+			return false
+		}); err != nil {
+			return first.Value, err
+		}
+
+		return first.Value, first.Err
+	})
+}
+
+// Any returns a future that resolves with the first of the futures to resolve,
+// the futures not settled yet are canceled, so futures shared with other consumers should not be passed.
+// It rejects with all the errors joined when all the futures reject.
+func Any[T any](futures ...*Future[T]) *Future[T] {
+	return NewWithContext(context.Background(), func(ctx context.Context) (T, error) {
+		var zero T
+
+		if len(futures) == 0 {
+			return zero, ErrNoFutures
+		}
+
 		var (
-			w          sync.WaitGroup
-			syncValues sync.Mutex
-			errOnce    sync.Once
-			err        error
+			value T
+			ok    bool
 		)
 
-		values := make([]T, len(futures))
+		errs := make([]error, len(futures))
 
-		for idx, future := range futures {
-			w.Add(1)
+		if err := collect(ctx, futures, func(i int, r Result[T]) bool {
+			if r.Err != nil {
+				errs[i] = r.Err
+				return true
+			}
 
-			go func(future *Future[T], i int) {
-				defer w.Done()
+			value, ok = r.Value, true
 
-				v, err := future.Await()
-				if err != nil {
-					errOnce.Do(func() {
-						future.err = err
-					})
-					return
-				}
+			return false
+		}); err != nil {
+			return zero, err
+		}
 
-				syncValues.Lock()
-				defer syncValues.Unlock()
+		if !ok {
+			return zero, errors.Join(errs...)
+		}
 
-				values[i] = v
-			}(future, idx)
+		return value, nil
+	})
+}
+
+// Map returns a future that resolves with the results of the function for the items in order.
+// At most limit functions are running concurrently, there is no limit if it is zero or less.
+// It rejects with the first error and cancels the context of the running functions.
+func Map[T, U any](ctx context.Context, items []T, limit int, fn func(context.Context, T) (U, error)) *Future[[]U] {
+	return NewWithContext(ctx, func(ctx context.Context) ([]U, error) {
+		values := make([]U, len(items))
+
+		err := forEach(ctx, items, limit, func(ctx context.Context, i int, item T) error {
+			v, err := fn(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			values[i] = v
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 
-		w.Wait()
+		return values, nil
+	})
+}
 
-		return values, err
+// ForEach returns a future that resolves when the function returned for all the items.
+// At most limit functions are running concurrently, there is no limit if it is zero or less.
+// It rejects with the first error and cancels the context of the running functions.
+func ForEach[T any](ctx context.Context, items []T, limit int, fn func(context.Context, T) error) *Future[struct{}] {
+	return NewWithContext(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, forEach(ctx, items, limit, func(ctx context.Context, _ int, item T) error {
+			return fn(ctx, item)
+		})
 	})
 }
+
+func forEach[T any](ctx context.Context, items []T, limit int, fn func(context.Context, int, T) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		wg  sync.WaitGroup
+		sem chan struct{}
+	)
+
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	for i, item := range items {
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+
+		// the slot may be released by a failed function after it canceled the context.
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Go(func() {
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+
+			if err := fn(ctx, i, item); err != nil {
+				cancel(err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return context.Cause(ctx)
+}
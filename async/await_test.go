@@ -1,11 +1,16 @@
 package async_test
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/katallaxie/pkg/async"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 )
 
 func BenchmarkAll(b *testing.B) {
@@ -34,3 +39,171 @@ func BenchmarkAll(b *testing.B) {
 		}
 	})
 }
+
+func blocked(t *testing.T) *async.Future[string] {
+	t.Helper()
+
+	return async.NewWithContext(t.Context(), func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+}
+
+func resolved[T any](v T, err error) *async.Future[T] {
+	return async.New(func() (T, error) {
+		return v, err
+	})
+}
+
+func TestAll(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	v, err := async.All(resolved("hello", nil), resolved("world", nil)).Await()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "world"}, v)
+
+	v, err = async.All[string]().Await()
+	require.NoError(t, err)
+	assert.Empty(t, v)
+}
+
+func TestAllFailFast(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+	loser := blocked(t)
+
+	_, err := async.All(loser, resolved("", errFailed)).Await()
+	require.ErrorIs(t, err, errFailed)
+
+	_, err = loser.Await()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAllSettled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+
+	v, err := async.AllSettled(resolved("hello", nil), resolved("", errFailed)).Await()
+	require.NoError(t, err)
+	require.Len(t, v, 2)
+	assert.Equal(t, async.Result[string]{Value: "hello"}, v[0])
+	require.ErrorIs(t, v[1].Err, errFailed)
+}
+
+func TestRace(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+	loser := blocked(t)
+
+	_, err := async.Race(loser, resolved("", errFailed)).Await()
+	require.ErrorIs(t, err, errFailed)
+
+	_, err = loser.Await()
+	require.ErrorIs(t, err, context.Canceled)
+
+	v, err := async.Race(blocked(t), resolved("hello", nil)).Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+
+	_, err = async.Race[string]().Await()
+	require.ErrorIs(t, err, async.ErrNoFutures)
+}
+
+func TestAny(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+	errOther := errors.New("other")
+	loser := blocked(t)
+
+	v, err := async.Any(resolved("", errFailed), loser, resolved("hello", nil)).Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+
+	_, err = loser.Await()
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = async.Any(resolved("", errFailed), resolved("", errOther)).Await()
+	require.ErrorIs(t, err, errFailed)
+	require.ErrorIs(t, err, errOther)
+
+	_, err = async.Any[string]().Await()
+	require.ErrorIs(t, err, async.ErrNoFutures)
+}
+
+func TestCombinatorCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	input := blocked(t)
+	f := async.All(input)
+	f.Cancel()
+
+	_, err := f.Await()
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = input.Await()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var running, peak atomic.Int32
+
+	v, err := async.Map(t.Context(), []int{1, 2, 3, 4, 5, 6}, 2, func(ctx context.Context, i int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		return i * i, nil
+	}).Await()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 4, 9, 16, 25, 36}, v)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestMapFailFast(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+
+	var calls atomic.Int32
+
+	_, err := async.Map(t.Context(), []int{1, 2, 3, 4, 5, 6}, 2, func(ctx context.Context, i int) (int, error) {
+		calls.Add(1)
+
+		if i == 1 {
+			return 0, errFailed
+		}
+
+		<-ctx.Done()
+
+		return 0, ctx.Err()
+	}).Await()
+	require.ErrorIs(t, err, errFailed)
+	assert.LessOrEqual(t, calls.Load(), int32(2))
+}
+
+func TestForEach(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var sum atomic.Int32
+
+	_, err := async.ForEach(t.Context(), []int32{1, 2, 3}, 0, func(ctx context.Context, i int32) error {
+		sum.Add(i)
+		return nil
+	}).Await()
+	require.NoError(t, err)
+	assert.Equal(t, int32(6), sum.Load())
+}
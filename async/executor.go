@@ -0,0 +1,343 @@
+package async
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrRejected is returned when the executor rejects a task.
+	ErrRejected = errors.New("async: task rejected")
+	// ErrExecutorClosed is returned when a task is submitted to a closed executor.
+	ErrExecutorClosed = errors.New("async: executor closed")
+)
+
+const (
+	// DefaultQueueSize is the default size of the queue of the executor.
+	DefaultQueueSize = 1024
+	// DefaultIdleTimeout is the default time an elastic worker is kept without tasks.
+	DefaultIdleTimeout = 30 * time.Second
+)
+
+// Executor runs tasks, e.g. the functions of futures.
+type Executor interface {
+	// Go runs the task, or returns an error if the task is rejected.
+	Go(task func()) error
+}
+
+// RejectionPolicy is the policy when the queue of the executor is full.
+type RejectionPolicy int
+
+const (
+	// Block is blocking until the task can be queued.
+	Block RejectionPolicy = iota
+	// Drop is dropping the task and returning ErrRejected.
+	Drop
+	// CallerRuns is running the task on the goroutine submitting it.
+	CallerRuns
+)
+
+// String returns the name of the rejection policy.
+func (p RejectionPolicy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case Drop:
+		return "drop"
+	case CallerRuns:
+		return "caller-runs"
+	default:
+		return "unknown"
+	}
+}
+
+// ExecutorStats are the metrics of the executor.
+type ExecutorStats struct {
+	// Workers is the number of running workers.
+	Workers int
+	// QueueDepth is the number of queued tasks.
+	QueueDepth int
+	// Submitted is the number of accepted tasks.
+	Submitted uint64
+	// Completed is the number of completed tasks.
+	Completed uint64
+	// Rejected is the number of rejected tasks.
+	Rejected uint64
+	// WaitTime is the total time the tasks waited in the queue.
+	WaitTime time.Duration
+	// RunTime is the total time the tasks ran.
+	RunTime time.Duration
+}
+
+// ExecutorOpts are the options for the executor.
+type ExecutorOpts struct {
+	// Workers is the number of workers which are always running.
+	Workers int
+	// MaxWorkers is the maximum number of workers, workers above Workers are
+	// started when the queue is full and stopped after IdleTimeout.
+	MaxWorkers int
+	// QueueSize is the size of the queue.
+	QueueSize int
+	// IdleTimeout is the time an elastic worker is kept without tasks.
+	IdleTimeout time.Duration
+	// Rejection is the policy when the queue is full and no worker can be started.
+	Rejection RejectionPolicy
+}
+
+// ExecutorOpt is a function that configures the executor options.
+type ExecutorOpt func(*ExecutorOpts)
+
+// Configure is a method that configures the executor options.
+func (o *ExecutorOpts) Configure(opts ...ExecutorOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultExecutorOpts returns the default options for the executor.
+func DefaultExecutorOpts() *ExecutorOpts {
+	return &ExecutorOpts{
+		Workers:     runtime.GOMAXPROCS(0),
+		QueueSize:   DefaultQueueSize,
+		IdleTimeout: DefaultIdleTimeout,
+		Rejection:   Block,
+	}
+}
+
+// WithWorkers is setting a fixed number of workers.
+func WithWorkers(n int) ExecutorOpt {
+	return func(opts *ExecutorOpts) {
+		opts.Workers = n
+		opts.MaxWorkers = n
+	}
+}
+
+// WithElasticWorkers is running min workers and up to max workers when the queue is full.
+func WithElasticWorkers(minWorkers, maxWorkers int) ExecutorOpt {
+	return func(opts *ExecutorOpts) {
+		opts.Workers = minWorkers
+		opts.MaxWorkers = maxWorkers
+	}
+}
+
+// WithQueueSize is setting the size of the queue.
+func WithQueueSize(n int) ExecutorOpt {
+	return func(opts *ExecutorOpts) {
+		opts.QueueSize = n
+	}
+}
+
+// WithIdleTimeout is setting the time an elastic worker is kept without tasks.
+func WithIdleTimeout(timeout time.Duration) ExecutorOpt {
+	return func(opts *ExecutorOpts) {
+		opts.IdleTimeout = timeout
+	}
+}
+
+// WithRejection is setting the policy when the queue is full.
+func WithRejection(policy RejectionPolicy) ExecutorOpt {
+	return func(opts *ExecutorOpts) {
+		opts.Rejection = policy
+	}
+}
+
+var _ Executor = (*executor)(nil)
+
+type task struct {
+	fn     func()
+	queued time.Time
+}
+
+type executor struct {
+	opts  *ExecutorOpts
+	queue chan task
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	// pending are the submissions in progress, the queue is closed once they returned.
+	pending sync.WaitGroup
+	mu      sync.RWMutex
+	closed  bool
+
+	workers   atomic.Int64
+	submitted atomic.Uint64
+	completed atomic.Uint64
+	rejected  atomic.Uint64
+	waitTime  atomic.Int64
+	runTime   atomic.Int64
+}
+
+// NewExecutor returns a new executor running the tasks on a pool of workers.
+// By default there are GOMAXPROCS workers, and submitting blocks when the queue is full.
+func NewExecutor(opts ...ExecutorOpt) *executor {
+	options := DefaultExecutorOpts()
+	options.Configure(opts...)
+
+	options.Workers = max(options.Workers, 1)
+	options.MaxWorkers = max(options.MaxWorkers, options.Workers)
+
+	e := new(executor)
+	e.opts = options
+	e.queue = make(chan task, max(options.QueueSize, 0))
+	e.done = make(chan struct{})
+
+	for range options.Workers {
+		e.workers.Add(1)
+		e.wg.Go(func() { e.work(false) })
+	}
+
+	return e
+}
+
+// Go runs the task on a worker, or applies the rejection policy when the queue is full.
+func (e *executor) Go(fn func()) error {
+	if !e.enter() {
+		return ErrExecutorClosed
+	}
+	defer e.pending.Done()
+
+	t := task{fn: fn, queued: time.Now()}
+
+	select {
+	case e.queue <- t:
+		e.submitted.Add(1)
+		return nil
+	default:
+	}
+
+	if e.spawn() {
+		e.submitted.Add(1)
+		e.wg.Go(func() {
+			e.run(t)
+			e.work(true)
+		})
+
+		return nil
+	}
+
+	switch e.opts.Rejection {
+	case Drop:
+		e.rejected.Add(1)
+		return ErrRejected
+	case CallerRuns:
+		e.submitted.Add(1)
+		e.run(t)
+
+		return nil
+	default:
+		select {
+		case e.queue <- t:
+			e.submitted.Add(1)
+			return nil
+		case <-e.done:
+			e.rejected.Add(1)
+			return ErrExecutorClosed
+		}
+	}
+}
+
+// enter registers a submission, it returns false if the executor is closed.
+func (e *executor) enter() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		return false
+	}
+
+	e.pending.Add(1)
+
+	return true
+}
+
+// Stats returns the metrics of the executor.
+func (e *executor) Stats() ExecutorStats {
+	return ExecutorStats{
+		Workers:    int(e.workers.Load()),
+		QueueDepth: len(e.queue),
+		Submitted:  e.submitted.Load(),
+		Completed:  e.completed.Load(),
+		Rejected:   e.rejected.Load(),
+		WaitTime:   time.Duration(e.waitTime.Load()),
+		RunTime:    time.Duration(e.runTime.Load()),
+	}
+}
+
+// Close stops accepting tasks and waits for the queued tasks to complete,
+// the submissions blocked on a full queue return ErrExecutorClosed.
+func (e *executor) Close() {
+	e.mu.Lock()
+	closed := e.closed
+	e.closed = true
+	e.mu.Unlock()
+
+	if !closed {
+		close(e.done)
+		e.pending.Wait()
+		close(e.queue)
+	}
+
+	e.wg.Wait()
+}
+
+// spawn reserves an elastic worker if the maximum is not reached.
+func (e *executor) spawn() bool {
+	for {
+		n := e.workers.Load()
+		if n >= int64(e.opts.MaxWorkers) {
+			return false
+		}
+
+		if e.workers.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+func (e *executor) work(elastic bool) {
+	defer e.workers.Add(-1)
+
+	var (
+		timer *time.Timer
+		idle  <-chan time.Time
+	)
+
+	if elastic {
+		timer = time.NewTimer(e.opts.IdleTimeout)
+		defer timer.Stop()
+
+		idle = timer.C
+	}
+
+	for {
+		select {
+		case t, ok := <-e.queue:
+			if !ok {
+				return
+			}
+
+			e.run(t)
+
+			if elastic {
+				timer.Reset(e.opts.IdleTimeout)
+			}
+		case <-idle:
+			return
+		}
+	}
+}
+
+func (e *executor) run(t task) {
+	start := time.Now()
+	e.waitTime.Add(int64(start.Sub(t.queued)))
+
+	defer func() {
+		e.runTime.Add(int64(time.Since(start)))
+		e.completed.Add(1)
+	}()
+
+	t.fn()
+}
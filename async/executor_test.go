@@ -0,0 +1,183 @@
+package async_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/async"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestExecutor(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := async.NewExecutor(async.WithWorkers(4))
+
+	futures := make([]*async.Future[int], 0, 100)
+	for i := range 100 {
+		futures = append(futures, async.New(func() (int, error) {
+			return i, nil
+		}, async.WithExecutor(e)))
+	}
+
+	v, err := async.All(futures...).Await()
+	require.NoError(t, err)
+	assert.Len(t, v, 100)
+	assert.Equal(t, 99, v[99])
+
+	e.Close()
+
+	stats := e.Stats()
+	assert.Equal(t, uint64(100), stats.Submitted)
+	assert.Equal(t, uint64(100), stats.Completed)
+	assert.Zero(t, stats.Workers)
+	assert.Zero(t, stats.QueueDepth)
+
+	_, err = async.New(func() (int, error) { return 0, nil }, async.WithExecutor(e)).Await()
+	require.ErrorIs(t, err, async.ErrExecutorClosed)
+}
+
+func TestExecutorDrop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := async.NewExecutor(async.WithWorkers(1), async.WithQueueSize(1), async.WithRejection(async.Drop))
+	defer e.Close()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	require.NoError(t, e.Go(func() {
+		close(started)
+		<-release
+	}))
+	<-started
+
+	require.NoError(t, e.Go(func() {}))
+	require.ErrorIs(t, e.Go(func() {}), async.ErrRejected)
+
+	stats := e.Stats()
+	assert.Equal(t, 1, stats.QueueDepth)
+	assert.Equal(t, uint64(1), stats.Rejected)
+
+	close(release)
+}
+
+func TestExecutorCallerRuns(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := async.NewExecutor(async.WithWorkers(1), async.WithQueueSize(1), async.WithRejection(async.CallerRuns))
+	defer e.Close()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	require.NoError(t, e.Go(func() {
+		close(started)
+		<-release
+	}))
+	<-started
+
+	require.NoError(t, e.Go(func() {}))
+
+	ran := false
+	require.NoError(t, e.Go(func() { ran = true }))
+	assert.True(t, ran)
+
+	close(release)
+}
+
+func TestExecutorElastic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := async.NewExecutor(
+		async.WithElasticWorkers(1, 3),
+		async.WithQueueSize(0),
+		async.WithIdleTimeout(10*time.Millisecond),
+		async.WithRejection(async.Drop),
+	)
+	defer e.Close()
+
+	release := make(chan struct{})
+
+	var started sync.WaitGroup
+
+	started.Add(3)
+
+	for range 3 {
+		require.Eventually(t, func() bool {
+			return e.Go(func() {
+				started.Done()
+				<-release
+			}) == nil
+		}, time.Second, time.Millisecond)
+	}
+
+	started.Wait()
+	assert.Equal(t, 3, e.Stats().Workers)
+	require.ErrorIs(t, e.Go(func() {}), async.ErrRejected)
+
+	close(release)
+
+	require.Eventually(t, func() bool { return e.Stats().Workers == 1 }, time.Second, time.Millisecond)
+}
+
+func TestExecutorBlock(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := async.NewExecutor(async.WithWorkers(2), async.WithQueueSize(1))
+
+	var wg sync.WaitGroup
+
+	for range 50 {
+		wg.Add(1)
+		require.NoError(t, e.Go(func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+		}))
+	}
+
+	wg.Wait()
+	e.Close()
+
+	stats := e.Stats()
+	assert.Equal(t, uint64(50), stats.Completed)
+	assert.Zero(t, stats.Rejected)
+	assert.Positive(t, stats.RunTime)
+}
+
+func TestExecutorCloseWhileSubmitting(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := async.NewExecutor(async.WithWorkers(1), async.WithQueueSize(1))
+
+	release := make(chan struct{})
+	submitted := make(chan error, 1)
+
+	require.NoError(t, e.Go(func() {
+		<-release
+		submitted <- e.Go(func() {})
+	}))
+	require.NoError(t, e.Go(func() {}))
+
+	closed := make(chan struct{})
+
+	go func() {
+		e.Close()
+		close(closed)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("close deadlocked")
+	}
+
+	require.ErrorIs(t, <-submitted, async.ErrExecutorClosed)
+	require.ErrorIs(t, e.Go(func() {}), async.ErrExecutorClosed)
+}
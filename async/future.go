@@ -1,20 +1,79 @@
 package async
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"sync"
 )
 
+// ErrSettled is returned when a settled future is settled again.
+var ErrSettled = errors.New("async: future already settled")
+
 // Future is a type that represents a future value.
 type Future[T any] struct {
-	value chan T
+	done  chan struct{}
+	value T
 	err   error
 
-	onthen  func(T)
-	oncatch func(error)
+	mu        sync.Mutex
+	callbacks []func()
+
+	cancel  context.CancelFunc
+	resolve sync.Once
+}
 
-	cache   *T
-	runOnce sync.Once
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// settle resolves the future with the value or rejects it with the error,
+// only the first call settles the future and returns true.
+func (f *Future[T]) settle(value T, err error) bool {
+	var (
+		callbacks []func()
+		settled   bool
+	)
+
+	f.resolve.Do(func() {
+		settled = true
+
+		f.value = value
+		f.err = err
+
+		f.mu.Lock()
+		close(f.done)
+		callbacks, f.callbacks = f.callbacks, nil
+		f.mu.Unlock()
+
+		if f.cancel != nil {
+			f.cancel()
+		}
+	})
+
+	for _, fn := range callbacks {
+		fn()
+	}
+
+	return settled
+}
+
+// onSettle calls the function on the resolving goroutine when the future settles,
+// or right away when the future is already settled.
+func (f *Future[T]) onSettle(fn func()) {
+	f.mu.Lock()
+
+	select {
+	case <-f.done:
+		f.mu.Unlock()
+		fn()
+
+		return
+	default:
+	}
+
+	f.callbacks = append(f.callbacks, fn)
+	f.mu.Unlock()
 }
 
 // Await  waits for the future to resolve and returns the value.
@@ -24,34 +83,93 @@ func Await[T any](f *Future[T]) (T, error) {
 
 // Await waits for the future to resolve and returns the value.
 func (f *Future[T]) Await() (T, error) {
-	f.runOnce.Do(func() {
-		v := <-f.value
-		f.cache = &v
+	<-f.done
+
+	return f.value, f.err
+}
+
+// AwaitContext waits for the future to resolve and returns the value,
+// or returns the error of the context when it is done before.
+// The future is not canceled when the context is done.
+func (f *Future[T]) AwaitContext(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed when the future resolves.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel cancels the context of the future and rejects it with context.Canceled
+// if it has not resolved yet.
+func (f *Future[T]) Cancel() {
+	var zero T
+	f.settle(zero, context.Canceled)
+}
+
+// Then returns a future that resolves with the result of the function called with the value of the future,
+// the function is not called and the error is passed on when the future rejects.
+// The function is called on the goroutine resolving the future.
+func Then[T, U any](f *Future[T], fn func(T) (U, error)) *Future[U] {
+	next := newFuture[U]()
+
+	f.onSettle(func() {
+		if f.err != nil {
+			var zero U
+			next.settle(zero, f.err)
+
+			return
+		}
+
+		next.settle(fn(f.value))
 	})
 
-	return *f.cache, f.err
+	return next
 }
 
-// Then sets a callback to be called when the future resolves.
-func (f *Future[T]) Then(fn func(T)) *Future[T] {
-	f.Await() //nolint:errcheck
+// Then returns a future that resolves with the result of the function called with the value of the future,
+// the function is not called and the error is passed on when the future rejects.
+// The function is called on the goroutine resolving the future.
+func (f *Future[T]) Then(fn func(T) (T, error)) *Future[T] {
+	return Then(f, fn)
+}
 
-	if f.err == nil {
-		fn(*f.cache)
-	}
+// Catch returns a future that resolves with the result of the function called with the error of the future,
+// so the function can recover into a value or return another error. The value is passed on when the future resolves.
+// The function is called on the goroutine resolving the future.
+func (f *Future[T]) Catch(fn func(error) (T, error)) *Future[T] {
+	next := newFuture[T]()
 
-	return f
+	f.onSettle(func() {
+		if f.err == nil {
+			next.settle(f.value, nil)
+			return
+		}
+
+		next.settle(fn(f.err))
+	})
+
+	return next
 }
 
-// Catch sets a callback to be called when the future rejects.
-func (f *Future[T]) Catch(fn func(error)) *Future[T] {
-	f.Await() //nolint:errcheck
+// Finally returns a future that settles like the future after the function was called,
+// the function is called whether the future resolves or rejects.
+// The function is called on the goroutine resolving the future.
+func (f *Future[T]) Finally(fn func()) *Future[T] {
+	next := newFuture[T]()
 
-	if f.err != nil {
-		fn(f.err)
-	}
+	f.onSettle(func() {
+		fn()
+		next.settle(f.value, f.err)
+	})
 
-	return f
+	return next
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -64,7 +182,21 @@ func (f *Future[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(val)
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
+// UnmarshalJSON implements the json.Unmarshaler interface,
+// it returns ErrSettled if the future is already settled.
 func (f *Future[T]) UnmarshalJSON(data []byte) error {
-	return json.Unmarshal(data, &f.cache)
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if f.done == nil {
+		f.done = make(chan struct{})
+	}
+
+	if !f.settle(v, nil) {
+		return ErrSettled
+	}
+
+	return nil
 }
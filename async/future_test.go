@@ -1 +1,283 @@
 package async_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/async"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestFutureAwait(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	f := async.New(func() (string, error) {
+		return "hello", nil
+	})
+
+	v, err := f.Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+
+	v, err = async.Await(f)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+}
+
+func TestFutureNotAwaited(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+
+	f := async.New(func() (string, error) {
+		return "", errFailed
+	})
+
+	<-f.Done()
+
+	_, err := f.Await()
+	require.ErrorIs(t, err, errFailed)
+}
+
+func TestFutureAwaitContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	release := make(chan struct{})
+
+	f := async.New(func() (string, error) {
+		<-release
+		return "hello", nil
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := f.AwaitContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+
+	v, err := f.AwaitContext(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+}
+
+func TestNewWithContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	f := async.NewWithContext(ctx, func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	cancel()
+
+	_, err := f.Await()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewWithContextIgnoringContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	f := async.NewWithContext(ctx, func(ctx context.Context) (string, error) {
+		<-release
+		return "hello", nil
+	})
+
+	cancel()
+
+	_, err := f.Await()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFutureCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	canceled := make(chan struct{})
+
+	f := async.NewWithContext(t.Context(), func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		close(canceled)
+
+		return "", ctx.Err()
+	})
+
+	f.Cancel()
+	<-canceled
+
+	_, err := f.Await()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWithTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	slow := async.NewWithContext(t.Context(), func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	_, err := async.WithTimeout(slow, 10*time.Millisecond).Await()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = slow.Await()
+	require.ErrorIs(t, err, context.Canceled)
+
+	fast := async.New(func() (string, error) {
+		return "hello", nil
+	})
+
+	v, err := async.WithTimeout(fast, time.Second).Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+}
+
+func TestFutureJSON(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	f := async.New(func() (string, error) {
+		return "hello", nil
+	})
+
+	b, err := json.Marshal(f)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"hello"`, string(b))
+
+	var g async.Future[string]
+	require.NoError(t, json.Unmarshal(b, &g))
+
+	v, err := g.Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`"world"`), &g), async.ErrSettled)
+
+	v, err = g.Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+}
+
+func TestThen(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	release := make(chan struct{})
+
+	f := async.New(func() (int, error) {
+		<-release
+		return 21, nil
+	})
+
+	doubled := f.Then(func(v int) (int, error) {
+		return v * 2, nil
+	})
+
+	formatted := async.Then(doubled, func(v int) (string, error) {
+		return strconv.Itoa(v), nil
+	})
+
+	close(release)
+
+	v, err := formatted.Await()
+	require.NoError(t, err)
+	assert.Equal(t, "42", v)
+
+	settled := async.Then(f, func(v int) (int, error) {
+		return v + 1, nil
+	})
+
+	n, err := settled.Await()
+	require.NoError(t, err)
+	assert.Equal(t, 22, n)
+}
+
+func TestThenRejected(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+	called := false
+
+	f := async.Then(resolved(0, errFailed), func(v int) (string, error) {
+		called = true
+		return "", nil
+	})
+
+	_, err := f.Await()
+	require.ErrorIs(t, err, errFailed)
+	assert.False(t, called)
+}
+
+func TestCatch(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+	errOther := errors.New("other")
+
+	v, err := resolved("", errFailed).Catch(func(err error) (string, error) {
+		return "recovered", nil
+	}).Await()
+	require.NoError(t, err)
+	assert.Equal(t, "recovered", v)
+
+	_, err = resolved("", errFailed).Catch(func(err error) (string, error) {
+		return "", errors.Join(errOther, err)
+	}).Await()
+	require.ErrorIs(t, err, errOther)
+	require.ErrorIs(t, err, errFailed)
+
+	v, err = resolved("hello", nil).Catch(func(err error) (string, error) {
+		return "recovered", nil
+	}).Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+}
+
+func TestFinally(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+
+	var calls atomic.Int32
+
+	v, err := resolved("hello", nil).Finally(func() { calls.Add(1) }).Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+
+	_, err = resolved("", errFailed).Finally(func() { calls.Add(1) }).Await()
+	require.ErrorIs(t, err, errFailed)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestThenResolvingGoroutine(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var steps []int
+
+	f := async.New(func() (int, error) {
+		return 0, nil
+	})
+
+	for i := range 100 {
+		f = f.Then(func(v int) (int, error) {
+			steps = append(steps, i)
+			return v + 1, nil
+		})
+	}
+
+	v, err := f.Await()
+	require.NoError(t, err)
+	assert.Equal(t, 100, v)
+	assert.Len(t, steps, 100)
+}
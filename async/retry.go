@@ -0,0 +1,222 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default maximum number of attempts.
+	DefaultMaxAttempts = 5
+	// DefaultMinBackoff is the default delay after the first failed attempt.
+	DefaultMinBackoff = 100 * time.Millisecond
+	// DefaultMaxBackoff is the default maximum delay between attempts.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// Backoff returns the delay before the next attempt,
+// with the number of failed attempts and the previous delay.
+type Backoff func(attempt int, prev time.Duration) time.Duration
+
+// Constant returns a backoff with a constant delay.
+func Constant(delay time.Duration) Backoff {
+	return func(int, time.Duration) time.Duration {
+		return delay
+	}
+}
+
+// Exponential returns a backoff doubling the delay from min up to max.
+func Exponential(minDelay, maxDelay time.Duration) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		delay := minDelay
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+
+		return min(delay, maxDelay)
+	}
+}
+
+// DecorrelatedJitter returns a backoff with a random delay between base
+// and three times the previous delay, up to max.
+func DecorrelatedJitter(base, maxDelay time.Duration) Backoff {
+	return func(_ int, prev time.Duration) time.Duration {
+		upper := max(prev*3, base)
+		if upper == base {
+			return min(base, maxDelay)
+		}
+
+		return min(base+rand.N(upper-base), maxDelay) //nolint:gosec
+	}
+}
+
+// RetryError is returned when the attempts of a policy are exhausted.
+type RetryError struct {
+	// Attempts is the number of attempts.
+	Attempts int
+	// Elapsed is the time since the first attempt.
+	Elapsed time.Duration
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("async: gave up after %d attempts in %s: %v", e.Attempts, e.Elapsed, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Policy is the policy to retry a function.
+type Policy struct {
+	// Backoff is the delay between attempts, an exponential backoff
+	// from DefaultMinBackoff to DefaultMaxBackoff is used if nil.
+	Backoff Backoff
+	// MaxAttempts is the maximum number of attempts, zero is unlimited.
+	MaxAttempts int
+	// MaxElapsed is the maximum time since the first attempt, zero is unlimited.
+	MaxElapsed time.Duration
+	// Retryable reports whether an error is retried, all errors are retried if nil.
+	Retryable func(error) bool
+	// OnRetry is called before waiting for the next attempt.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// PolicyOpt is a function that configures the policy.
+type PolicyOpt func(*Policy)
+
+// Configure is a method that configures the policy.
+func (p *Policy) Configure(opts ...PolicyOpt) {
+	for _, opt := range opts {
+		opt(p)
+	}
+}
+
+// DefaultPolicy returns the default policy with an exponential backoff.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Backoff:     Exponential(DefaultMinBackoff, DefaultMaxBackoff),
+		MaxAttempts: DefaultMaxAttempts,
+	}
+}
+
+// NewPolicy returns a new policy, starting with the default policy.
+func NewPolicy(opts ...PolicyOpt) *Policy {
+	p := DefaultPolicy()
+	p.Configure(opts...)
+
+	return p
+}
+
+// WithBackoff is setting the backoff of the policy.
+func WithBackoff(backoff Backoff) PolicyOpt {
+	return func(p *Policy) {
+		p.Backoff = backoff
+	}
+}
+
+// WithMaxAttempts is setting the maximum number of attempts, zero is unlimited.
+func WithMaxAttempts(attempts int) PolicyOpt {
+	return func(p *Policy) {
+		p.MaxAttempts = attempts
+	}
+}
+
+// WithMaxElapsed is setting the maximum time since the first attempt, zero is unlimited.
+func WithMaxElapsed(elapsed time.Duration) PolicyOpt {
+	return func(p *Policy) {
+		p.MaxElapsed = elapsed
+	}
+}
+
+// WithRetryable is setting the function reporting whether an error is retried.
+func WithRetryable(fn func(error) bool) PolicyOpt {
+	return func(p *Policy) {
+		p.Retryable = fn
+	}
+}
+
+// WithOnRetry is setting the function called before waiting for the next attempt.
+func WithOnRetry(fn func(attempt int, err error, delay time.Duration)) PolicyOpt {
+	return func(p *Policy) {
+		p.OnRetry = fn
+	}
+}
+
+// Do calls the function until it returns no error or the policy gives up,
+// then a *RetryError with the last error is returned.
+func (p *Policy) Do(ctx context.Context, fn func(context.Context) error) error {
+	start := time.Now()
+
+	backoff := p.Backoff
+	if backoff == nil {
+		backoff = Exponential(DefaultMinBackoff, DefaultMaxBackoff)
+	}
+
+	var delay time.Duration
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		giveUp := func(err error) error {
+			return &RetryError{Attempts: attempt, Elapsed: time.Since(start), Err: err}
+		}
+
+		if p.Retryable != nil && !p.Retryable(err) {
+			return giveUp(err)
+		}
+
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			return giveUp(err)
+		}
+
+		delay = backoff(attempt, delay)
+
+		if p.MaxElapsed > 0 && time.Since(start)+delay > p.MaxElapsed {
+			return giveUp(err)
+		}
+
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return giveUp(errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+}
+
+// Retry returns a future that resolves with the first value the function returns without an error,
+// or rejects with a *RetryError when the policy gives up.
+func Retry[T any](ctx context.Context, p *Policy, fn func(context.Context) (T, error)) *Future[T] {
+	return NewWithContext(ctx, func(ctx context.Context) (T, error) {
+		var value T
+
+		err := p.Do(ctx, func(ctx context.Context) error {
+			v, err := fn(ctx)
+			if err != nil {
+				return err
+			}
+
+			value = v
+
+			return nil
+		})
+
+		return value, err
+	})
+}
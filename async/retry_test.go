@@ -0,0 +1,173 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/async"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, async.Constant(time.Second)(3, 0))
+
+	exp := async.Exponential(100*time.Millisecond, time.Second)
+	assert.Equal(t, 100*time.Millisecond, exp(1, 0))
+	assert.Equal(t, 200*time.Millisecond, exp(2, 0))
+	assert.Equal(t, 800*time.Millisecond, exp(4, 0))
+	assert.Equal(t, time.Second, exp(10, 0))
+
+	jitter := async.DecorrelatedJitter(100*time.Millisecond, time.Second)
+	assert.Equal(t, 100*time.Millisecond, jitter(1, 0))
+
+	for range 100 {
+		d := jitter(2, 200*time.Millisecond)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.Less(t, d, 600*time.Millisecond)
+		assert.LessOrEqual(t, jitter(3, d*10), time.Second)
+	}
+}
+
+func TestPolicyDo(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	var retries []int
+
+	p := async.NewPolicy(
+		async.WithBackoff(async.Constant(time.Millisecond)),
+		async.WithOnRetry(func(attempt int, err error, delay time.Duration) {
+			retries = append(retries, attempt)
+		}),
+	)
+
+	calls := 0
+	err := p.Do(t.Context(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errFailed
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, retries)
+}
+
+func TestPolicyMaxAttempts(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	p := async.NewPolicy(async.WithBackoff(async.Constant(0)), async.WithMaxAttempts(4))
+
+	err := p.Do(t.Context(), func(ctx context.Context) error {
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+
+	var retryErr *async.RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 4, retryErr.Attempts)
+}
+
+func TestPolicyMaxElapsed(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	p := async.NewPolicy(
+		async.WithBackoff(async.Constant(20*time.Millisecond)),
+		async.WithMaxAttempts(0),
+		async.WithMaxElapsed(50*time.Millisecond),
+	)
+
+	err := p.Do(t.Context(), func(ctx context.Context) error {
+		return errFailed
+	})
+
+	var retryErr *async.RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.GreaterOrEqual(t, retryErr.Attempts, 2)
+	assert.Less(t, retryErr.Elapsed, 50*time.Millisecond)
+}
+
+func TestPolicyRetryable(t *testing.T) {
+	errFailed := errors.New("failed")
+	errPermanent := errors.New("permanent")
+
+	p := async.NewPolicy(
+		async.WithBackoff(async.Constant(0)),
+		async.WithRetryable(func(err error) bool { return !errors.Is(err, errPermanent) }),
+	)
+
+	calls := 0
+	err := p.Do(t.Context(), func(ctx context.Context) error {
+		calls++
+		if calls == 2 {
+			return errPermanent
+		}
+
+		return errFailed
+	})
+	require.ErrorIs(t, err, errPermanent)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPolicyContext(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	p := async.NewPolicy(
+		async.WithBackoff(async.Constant(time.Hour)),
+		async.WithOnRetry(func(int, error, time.Duration) { cancel() }),
+	)
+
+	err := p.Do(ctx, func(ctx context.Context) error {
+		return errFailed
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, errFailed)
+}
+
+func TestPolicyZero(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var delays []time.Duration
+
+	p := &async.Policy{
+		OnRetry: func(_ int, _ error, delay time.Duration) {
+			delays = append(delays, delay)
+			cancel()
+		},
+	}
+
+	err := p.Do(ctx, func(ctx context.Context) error {
+		return errFailed
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []time.Duration{async.DefaultMinBackoff}, delays)
+}
+
+func TestRetry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFailed := errors.New("failed")
+	calls := 0
+
+	f := async.Retry(t.Context(), async.NewPolicy(async.WithBackoff(async.Constant(time.Millisecond))), func(ctx context.Context) (string, error) {
+		calls++
+		if calls < 2 {
+			return "", errFailed
+		}
+
+		return "hello", nil
+	})
+
+	v, err := f.Await()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+}
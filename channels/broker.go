@@ -0,0 +1,315 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrBrokerClosed is returned when the broker is closed.
+	ErrBrokerClosed = errors.New("channels: broker closed")
+	// ErrInvalidTopic is returned when a topic or a pattern is invalid.
+	ErrInvalidTopic = errors.New("channels: invalid topic")
+)
+
+const (
+	// DefaultBuffer is the default buffer of a subscription.
+	DefaultBuffer = 16
+
+	separator = "."
+	// wildcard is matching a single segment of a topic.
+	wildcard = "*"
+	// fullWildcard is matching one or more trailing segments of a topic.
+	fullWildcard = ">"
+)
+
+// Message is a message published to a topic.
+type Message[T any] struct {
+	// Topic is the topic the message was published to.
+	Topic string
+	// Payload is the payload of the message.
+	Payload T
+}
+
+// BrokerOpts are the options for the subscriptions of a broker.
+type BrokerOpts struct {
+	// Buffer is the buffer of a subscription.
+	Buffer int
+	// Policy is the policy when the buffer of a subscription is full.
+	Policy Policy
+}
+
+// BrokerOpt is a function that configures the broker options.
+type BrokerOpt func(*BrokerOpts)
+
+// Configure is a method that configures the broker options.
+func (o *BrokerOpts) Configure(opts ...BrokerOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultBrokerOpts returns the default options for the broker.
+func DefaultBrokerOpts() *BrokerOpts {
+	return &BrokerOpts{
+		Buffer: DefaultBuffer,
+		Policy: Block,
+	}
+}
+
+// WithBuffer is setting the buffer of a subscription.
+func WithBuffer(n int) BrokerOpt {
+	return func(opts *BrokerOpts) {
+		opts.Buffer = n
+	}
+}
+
+// WithPolicy is setting the policy when the buffer of a subscription is full.
+func WithPolicy(policy Policy) BrokerOpt {
+	return func(opts *BrokerOpts) {
+		opts.Policy = policy
+	}
+}
+
+// Subscription is a subscription to the topics matching a pattern.
+type Subscription[T any] struct {
+	pattern []string
+	policy  Policy
+	broker  *Broker[T]
+
+	ch      chan Message[T]
+	done    chan struct{}
+	mu      sync.RWMutex
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// C returns the channel of the messages, it is closed when the subscription ends.
+func (s *Subscription[T]) C() <-chan Message[T] {
+	return s.ch
+}
+
+// Pattern returns the pattern of the subscription.
+func (s *Subscription[T]) Pattern() string {
+	return strings.Join(s.pattern, separator)
+}
+
+// Dropped returns the number of messages dropped by the policy.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe ends the subscription and closes the channel,
+// the buffered messages can still be received.
+func (s *Subscription[T]) Unsubscribe() {
+	s.broker.remove(s)
+	s.close()
+}
+
+func (s *Subscription[T]) close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
+
+func (s *Subscription[T]) send(ctx context.Context, msg Message[T]) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-s.done:
+		return true
+	default:
+	}
+
+	if deliver(ctx, s.done, s.ch, msg, s.policy) {
+		return true
+	}
+
+	if ctx.Err() != nil {
+		return true
+	}
+
+	s.dropped.Add(1)
+
+	return false
+}
+
+// Broker is publishing messages to the subscriptions of the matching topics.
+// Topics are separated by dots, in patterns "*" matches a single segment,
+// and ">" at the end matches one or more segments, e.g. "cache.*.invalidate" or "ws.>".
+type Broker[T any] struct {
+	opts *BrokerOpts
+
+	mu     sync.RWMutex
+	subs   map[*Subscription[T]]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewBroker returns a new broker with the default options for the subscriptions.
+func NewBroker[T any](opts ...BrokerOpt) *Broker[T] {
+	options := DefaultBrokerOpts()
+	options.Configure(opts...)
+
+	b := new(Broker[T])
+	b.opts = options
+	b.subs = make(map[*Subscription[T]]struct{})
+
+	return b
+}
+
+// Subscribe subscribes to the topics matching the pattern,
+// the options override the options of the broker.
+func (b *Broker[T]) Subscribe(pattern string, opts ...BrokerOpt) (*Subscription[T], error) {
+	segments, err := parseTopic(pattern, true)
+	if err != nil {
+		return nil, err
+	}
+
+	options := *b.opts
+	options.Configure(opts...)
+
+	s := &Subscription[T]{
+		pattern: segments,
+		policy:  options.Policy,
+		broker:  b,
+		ch:      make(chan Message[T], max(options.Buffer, 0)),
+		done:    make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	b.subs[s] = struct{}{}
+
+	return s, nil
+}
+
+// Publish publishes the payload to the subscriptions matching the topic.
+// It blocks on subscriptions with the Block policy until they have room,
+// or returns the error of the context when it is done.
+func (b *Broker[T]) Publish(ctx context.Context, topic string, payload T) error {
+	segments, err := parseTopic(topic, false)
+	if err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBrokerClosed
+	}
+
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	subs := make([]*Subscription[T], 0, len(b.subs))
+	for s := range b.subs {
+		if match(s.pattern, segments) {
+			subs = append(subs, s)
+		}
+	}
+
+	b.mu.RUnlock()
+
+	msg := Message[T]{Topic: topic, Payload: payload}
+
+	for _, s := range subs {
+		if !s.send(ctx, msg) && s.policy == Disconnect {
+			s.Unsubscribe()
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Close stops accepting messages and subscriptions, waits for the pending publishes,
+// and closes the subscriptions. The subscribers still receive the buffered messages.
+// The blocked publishes are canceled when the context is done.
+func (b *Broker[T]) Close(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	published := make(chan struct{})
+
+	go func() {
+		b.wg.Wait()
+		close(published)
+	}()
+
+	var err error
+
+	select {
+	case <-published:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = make(map[*Subscription[T]]struct{})
+	b.mu.Unlock()
+
+	for s := range subs {
+		s.close()
+	}
+
+	<-published
+
+	return err
+}
+
+func (b *Broker[T]) remove(s *Subscription[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs, s)
+}
+
+func parseTopic(topic string, pattern bool) ([]string, error) {
+	segments := strings.Split(topic, separator)
+
+	for i, segment := range segments {
+		switch {
+		case segment == "",
+			!pattern && (segment == wildcard || segment == fullWildcard),
+			segment == fullWildcard && i != len(segments)-1:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTopic, topic)
+		}
+	}
+
+	return segments, nil
+}
+
+func match(pattern, topic []string) bool {
+	for i, segment := range pattern {
+		if segment == fullWildcard {
+			return len(topic) > i
+		}
+
+		if i >= len(topic) || (segment != wildcard && segment != topic[i]) {
+			return false
+		}
+	}
+
+	return len(pattern) == len(topic)
+}
@@ -0,0 +1,175 @@
+package channels
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func payloads[T any](s *Subscription[T]) []T {
+	var values []T
+	for msg := range s.C() {
+		values = append(values, msg.Payload)
+	}
+
+	return values
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		match   bool
+	}{
+		{"cache.users", "cache.users", true},
+		{"cache.users", "cache.groups", false},
+		{"cache.*", "cache.users", true},
+		{"cache.*", "cache.users.1", false},
+		{"cache.*.invalidate", "cache.users.invalidate", true},
+		{"cache.>", "cache.users.1", true},
+		{"cache.>", "cache", false},
+		{">", "cache", true},
+		{"*", "cache.users", false},
+	}
+
+	for _, tc := range tests {
+		pattern, err := parseTopic(tc.pattern, true)
+		require.NoError(t, err)
+
+		topic, err := parseTopic(tc.topic, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.match, match(pattern, topic), "%s %s", tc.pattern, tc.topic)
+	}
+}
+
+func TestBrokerInvalidTopic(t *testing.T) {
+	b := NewBroker[int]()
+
+	for _, pattern := range []string{"", "cache..users", "cache.>.users"} {
+		_, err := b.Subscribe(pattern)
+		require.ErrorIs(t, err, ErrInvalidTopic, pattern)
+	}
+
+	require.ErrorIs(t, b.Publish(t.Context(), "cache.*", 1), ErrInvalidTopic)
+}
+
+func TestBroker(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	b := NewBroker[string]()
+
+	users, err := b.Subscribe("cache.users")
+	require.NoError(t, err)
+
+	all, err := b.Subscribe("cache.>")
+	require.NoError(t, err)
+
+	require.NoError(t, b.Publish(t.Context(), "cache.users", "alice"))
+	require.NoError(t, b.Publish(t.Context(), "cache.groups", "admins"))
+	require.NoError(t, b.Publish(t.Context(), "ws.users", "bob"))
+
+	msg := <-users.C()
+	assert.Equal(t, Message[string]{Topic: "cache.users", Payload: "alice"}, msg)
+
+	require.NoError(t, b.Close(t.Context()))
+
+	assert.Empty(t, payloads(users))
+	assert.Equal(t, []string{"alice", "admins"}, payloads(all))
+
+	require.ErrorIs(t, b.Publish(t.Context(), "cache.users", "carol"), ErrBrokerClosed)
+
+	_, err = b.Subscribe("cache.users")
+	require.ErrorIs(t, err, ErrBrokerClosed)
+}
+
+func TestBrokerUnsubscribe(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	b := NewBroker[int]()
+
+	s, err := b.Subscribe("numbers")
+	require.NoError(t, err)
+
+	require.NoError(t, b.Publish(t.Context(), "numbers", 1))
+
+	s.Unsubscribe()
+	s.Unsubscribe()
+
+	require.NoError(t, b.Publish(t.Context(), "numbers", 2))
+	assert.Equal(t, []int{1}, payloads(s))
+}
+
+func TestBrokerSlowConsumer(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	b := NewBroker[int](WithBuffer(2))
+
+	newest, err := b.Subscribe("numbers", WithPolicy(DropNewest))
+	require.NoError(t, err)
+
+	oldest, err := b.Subscribe("numbers", WithPolicy(DropOldest))
+	require.NoError(t, err)
+
+	disconnect, err := b.Subscribe("numbers", WithPolicy(Disconnect))
+	require.NoError(t, err)
+
+	for i := range 4 {
+		require.NoError(t, b.Publish(t.Context(), "numbers", i))
+	}
+
+	assert.Equal(t, []int{0, 1}, payloads(disconnect))
+	assert.Equal(t, uint64(1), disconnect.Dropped())
+
+	require.NoError(t, b.Close(t.Context()))
+
+	assert.Equal(t, []int{0, 1}, payloads(newest))
+	assert.Equal(t, uint64(2), newest.Dropped())
+	assert.Equal(t, []int{2, 3}, payloads(oldest))
+}
+
+func TestBrokerBlock(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	b := NewBroker[int](WithBuffer(0))
+
+	s, err := b.Subscribe("numbers")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, b.Publish(ctx, "numbers", 1), context.DeadlineExceeded)
+
+	published := make(chan error)
+	go func() { published <- b.Publish(t.Context(), "numbers", 2) }()
+
+	assert.Equal(t, 2, (<-s.C()).Payload)
+	require.NoError(t, <-published)
+}
+
+func TestBrokerCloseTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	b := NewBroker[int](WithBuffer(0))
+
+	s, err := b.Subscribe("numbers")
+	require.NoError(t, err)
+
+	published := make(chan error, 1)
+	go func() { published <- b.Publish(t.Context(), "numbers", 1) }()
+
+	// the publish is blocked on the subscription without a reader.
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, b.Close(ctx), context.DeadlineExceeded)
+	require.NoError(t, <-published)
+	assert.Empty(t, payloads(s))
+}
@@ -0,0 +1,418 @@
+package channels
+
+import (
+	"context"
+	"time"
+)
+
+// Policy is the policy when the buffer of an output is full.
+type Policy int
+
+const (
+	// Block is blocking until the output has room for the value.
+	Block Policy = iota
+	// DropNewest is dropping the value when the output is full.
+	DropNewest
+	// DropOldest is dropping the oldest buffered value of the output to make room for the value.
+	DropOldest
+	// Disconnect is dropping the value and closing the output when it is full.
+	Disconnect
+)
+
+// String returns the name of the policy.
+func (p Policy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case DropNewest:
+		return "drop-newest"
+	case DropOldest:
+		return "drop-oldest"
+	case Disconnect:
+		return "disconnect"
+	default:
+		return "unknown"
+	}
+}
+
+// send sends the value to the output, it returns false when the context is done.
+func send[T any](ctx context.Context, output chan<- T, v T) bool {
+	select {
+	case output <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive receives a value from the input, it returns false when the input is closed or the context is done.
+func receive[T any](ctx context.Context, input <-chan T) (T, bool) {
+	select {
+	case v, ok := <-input:
+		return v, ok
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// deliver sends the value to the output with the policy, it returns false when the value was dropped,
+// or when the context or done is done while blocking.
+func deliver[T any](ctx context.Context, done <-chan struct{}, output chan T, v T, policy Policy) bool {
+	switch policy {
+	case DropNewest, Disconnect:
+		select {
+		case output <- v:
+			return true
+		default:
+			return false
+		}
+	case DropOldest:
+		for {
+			select {
+			case output <- v:
+				return true
+			default:
+			}
+
+			select {
+			case <-output:
+			default:
+				// an unbuffered output has no oldest value to drop.
+				if cap(output) == 0 {
+					return false
+				}
+			}
+		}
+	default:
+		select {
+		case output <- v:
+			return true
+		case <-ctx.Done():
+			return false
+		case <-done:
+			return false
+		}
+	}
+}
+
+// Map maps the values of the input with the function.
+// The output is closed when the input is closed or the context is done.
+func Map[T, U any](ctx context.Context, input <-chan T, fn func(T) U) <-chan U {
+	out := make(chan U)
+
+	go func() {
+		defer close(out)
+
+		for {
+			v, ok := receive(ctx, input)
+			if !ok || !send(ctx, out, fn(v)) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// FlatMap maps each value of the input to multiple values with the function.
+// The output is closed when the input is closed or the context is done.
+func FlatMap[T, U any](ctx context.Context, input <-chan T, fn func(T) []U) <-chan U {
+	out := make(chan U)
+
+	go func() {
+		defer close(out)
+
+		for {
+			v, ok := receive(ctx, input)
+			if !ok {
+				return
+			}
+
+			for _, u := range fn(v) {
+				if !send(ctx, out, u) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// MapN maps the values of the input with the function on n goroutines,
+// the values are sent to the output in the order of the input.
+// The output is closed when the input is closed or the context is done.
+func MapN[T, U any](ctx context.Context, input <-chan T, n int, fn func(T) U) <-chan U {
+	n = max(n, 1)
+
+	out := make(chan U)
+	results := make(chan chan U, n)
+
+	go func() {
+		defer close(results)
+
+		sem := make(chan struct{}, n)
+
+		for {
+			v, ok := receive(ctx, input)
+			if !ok || !send(ctx, sem, struct{}{}) {
+				return
+			}
+
+			result := make(chan U, 1)
+
+			go func() {
+				defer func() { <-sem }()
+				result <- fn(v)
+			}()
+
+			if !send(ctx, results, result) {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+
+		for result := range results {
+			u, ok := receive(ctx, result)
+			if !ok || !send(ctx, out, u) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Batch groups the values of the input into batches of the size,
+// a batch is sent earlier when maxWait has passed since its first value.
+// The last batch is sent when the input is closed, the output is closed
+// when the input is closed or the context is done.
+func Batch[T any](ctx context.Context, input <-chan T, size int, maxWait time.Duration) <-chan []T {
+	size = max(size, 1)
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		var (
+			batch   []T
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timeout = nil
+			}
+
+			if len(batch) == 0 {
+				return true
+			}
+
+			b := batch
+			batch = nil
+
+			return send(ctx, out, b)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-input:
+				if !ok {
+					flush()
+					return
+				}
+
+				batch = append(batch, v)
+
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+
+				if len(batch) >= size && !flush() {
+					return
+				}
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// Debounce sends the last value of the input once no value was received for the duration.
+// The pending value is sent when the input is closed, the output is closed
+// when the input is closed or the context is done.
+func Debounce[T any](ctx context.Context, input <-chan T, d time.Duration) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		timer := time.NewTimer(d)
+		timer.Stop()
+
+		var (
+			last    T
+			pending bool
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-input:
+				if !ok {
+					if pending {
+						send(ctx, out, last)
+					}
+
+					return
+				}
+
+				last, pending = v, true
+				timer.Reset(d)
+			case <-timer.C:
+				pending = false
+
+				if !send(ctx, out, last) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// Throttle sends the values of the input with at least the duration between them,
+// the input is not read while waiting, so the backpressure is passed on to the producer.
+// The output is closed when the input is closed or the context is done.
+func Throttle[T any](ctx context.Context, input <-chan T, d time.Duration) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		var last time.Time
+
+		for {
+			v, ok := receive(ctx, input)
+			if !ok {
+				return
+			}
+
+			if wait := d - time.Since(last); wait > 0 {
+				timer := time.NewTimer(wait)
+
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+
+			if !send(ctx, out, v) {
+				return
+			}
+
+			last = time.Now()
+		}
+	}()
+
+	return out
+}
+
+// Tee sends every value of the input to both outputs,
+// the next value is read once both outputs have received the value.
+// The outputs are closed when the input is closed or the context is done.
+func Tee[T any](ctx context.Context, input <-chan T) (<-chan T, <-chan T) {
+	out1 := make(chan T)
+	out2 := make(chan T)
+
+	go func() {
+		defer close(out1)
+		defer close(out2)
+
+		for {
+			v, ok := receive(ctx, input)
+			if !ok {
+				return
+			}
+
+			o1, o2 := out1, out2
+
+			for range 2 {
+				select {
+				case <-ctx.Done():
+					return
+				case o1 <- v:
+					o1 = nil
+				case o2 <- v:
+					o2 = nil
+				}
+			}
+		}
+	}()
+
+	return out1, out2
+}
+
+// BroadcastContext sends every value of the input to n outputs with a buffer of the size,
+// the policy decides what happens when the buffer of an output is full, so a slow output
+// does not have to block the others. The outputs are closed when the input is closed or the context is done.
+func BroadcastContext[T any](ctx context.Context, input <-chan T, n, buffer int, policy Policy) []<-chan T {
+	outputs := make([]chan T, n)
+	results := make([]<-chan T, n)
+
+	for i := range outputs {
+		outputs[i] = make(chan T, max(buffer, 0))
+		results[i] = outputs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, output := range outputs {
+				if output != nil {
+					close(output)
+				}
+			}
+		}()
+
+		for {
+			v, ok := receive(ctx, input)
+			if !ok {
+				return
+			}
+
+			for i, output := range outputs {
+				if output == nil {
+					continue
+				}
+
+				if !deliver(ctx, nil, output, v, policy) && policy == Disconnect {
+					close(output)
+					outputs[i] = nil
+				}
+
+				if ctx.Err() != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return results
+}
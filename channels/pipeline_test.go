@@ -0,0 +1,214 @@
+package channels
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func source[T any](values ...T) <-chan T {
+	c := make(chan T, len(values))
+	for _, v := range values {
+		c <- v
+	}
+	close(c)
+
+	return c
+}
+
+func collect[T any](c <-chan T) []T {
+	var values []T
+	for v := range c {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+func TestMap(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	out := Map(t.Context(), source(1, 2, 3), func(v int) int { return v * 2 })
+	assert.Equal(t, []int{2, 4, 6}, collect(out))
+}
+
+func TestMapCancel(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	in := make(chan int)
+	out := Map(ctx, in, func(v int) int { return v })
+
+	cancel()
+
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestFlatMap(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	out := FlatMap(t.Context(), source(1, 2), func(v int) []int { return []int{v, v} })
+	assert.Equal(t, []int{1, 1, 2, 2}, collect(out))
+}
+
+func TestMapN(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = i
+	}
+
+	out := MapN(t.Context(), source(values...), 8, func(v int) int {
+		time.Sleep(time.Duration(100-v) * time.Microsecond)
+		return v * 2
+	})
+
+	result := collect(out)
+	require.Len(t, result, 100)
+	assert.True(t, slices.IsSorted(result))
+}
+
+func TestMapNCancel(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	in := make(chan int)
+	defer close(in)
+
+	out := MapN(ctx, in, 4, func(v int) int { return v })
+
+	in <- 1
+	assert.Equal(t, 1, <-out)
+
+	cancel()
+
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestBatch(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	out := Batch(t.Context(), source(1, 2, 3, 4, 5), 2, time.Second)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, collect(out))
+}
+
+func TestBatchMaxWait(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	in := make(chan int)
+	out := Batch(t.Context(), in, 10, 10*time.Millisecond)
+
+	in <- 1
+	in <- 2
+
+	assert.Equal(t, []int{1, 2}, <-out)
+
+	close(in)
+
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestDebounce(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	in := make(chan int)
+	out := Debounce(t.Context(), in, 20*time.Millisecond)
+
+	in <- 1
+	in <- 2
+	in <- 3
+
+	assert.Equal(t, 3, <-out)
+
+	in <- 4
+	close(in)
+
+	assert.Equal(t, []int{4}, collect(out))
+}
+
+func TestThrottle(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	start := time.Now()
+	out := Throttle(t.Context(), source(1, 2, 3), 10*time.Millisecond)
+
+	assert.Equal(t, []int{1, 2, 3}, collect(out))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestTee(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	out1, out2 := Tee(t.Context(), source(1, 2, 3))
+
+	done := make(chan []int)
+	go func() { done <- collect(out2) }()
+
+	assert.Equal(t, []int{1, 2, 3}, collect(out1))
+	assert.Equal(t, []int{1, 2, 3}, <-done)
+}
+
+func TestBroadcastContext(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	outputs := BroadcastContext(t.Context(), source(1, 2, 3), 2, 3, Block)
+	require.Len(t, outputs, 2)
+
+	for _, output := range outputs {
+		assert.Equal(t, []int{1, 2, 3}, collect(output))
+	}
+}
+
+func TestBroadcastContextDrop(t *testing.T) {
+	ignore := goleak.IgnoreCurrent()
+	defer goleak.VerifyNone(t, ignore)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	in := make(chan int)
+
+	newest := BroadcastContext(ctx, in, 1, 1, DropNewest)[0]
+	oldest := BroadcastContext(ctx, source(1, 2, 3), 1, 1, DropOldest)[0]
+
+	in <- 1
+	in <- 2
+	in <- 3
+	close(in)
+
+	// the outputs are read once the broadcasts have delivered all values and returned.
+	require.NoError(t, goleak.Find(ignore))
+
+	assert.Equal(t, []int{1}, collect(newest))
+	assert.Equal(t, []int{3}, collect(oldest))
+}
+
+func TestBroadcastContextCancel(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	in := make(chan int)
+	outputs := BroadcastContext(ctx, in, 2, 0, Block)
+
+	go func() { in <- 1 }()
+
+	assert.Equal(t, 1, <-outputs[0])
+
+	cancel()
+
+	for _, output := range outputs {
+		Drain(output)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/katallaxie/pkg/async"
 	"github.com/katallaxie/pkg/cmd/waitfor/schemes"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -63,29 +64,30 @@ func waitFor(ctx context.Context, urlStr string) error {
 		return fmt.Errorf("url parse '%s': %w", urlStr, err)
 	}
 
-	t := time.NewTicker(cfg.retryTime)
-	defer t.Stop()
+	fn, ok := waitFuncs[u.Scheme]
+	if !ok {
+		return fmt.Errorf("unsupported schema %q", u.Scheme)
+	}
 
-	for {
-		fn, ok := waitFuncs[u.Scheme]
-		if !ok {
-			return fmt.Errorf("unsupported schema %q", u.Scheme)
-		}
+	policy := async.NewPolicy(
+		async.WithBackoff(async.Constant(cfg.retryTime)),
+		async.WithMaxAttempts(0),
+		async.WithOnRetry(func(_ int, err error, _ time.Duration) {
+			log.Println("Waiting for", urlStr, err)
+		}),
+	)
 
+	err = policy.Do(ctx, func(ctx context.Context) error {
 		ct, cancel := context.WithTimeout(ctx, cfg.connTimeout)
-		err = fn(ct, urlStr)
-		cancel()
-		if err == nil {
-			return nil
-		}
+		defer cancel()
 
-		log.Println("Waiting for", urlStr, err)
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timeout waiting for %s", urlStr)
-		case <-t.C:
-		}
+		return fn(ct, urlStr)
+	})
+	if err != nil {
+		return fmt.Errorf("timeout waiting for %s: %w", urlStr, err)
 	}
+
+	return nil
 }
 
 func runRoot(ctx context.Context, args ...string) error {
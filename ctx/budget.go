@@ -0,0 +1,54 @@
+package ctx
+
+import (
+	"context"
+	"time"
+)
+
+// Budget splits the time remaining until the deadline of a context into fractional sub-budgets,
+// e.g. 30% of the time for a database call and 60% for a downstream call.
+type Budget struct {
+	ctx      context.Context
+	total    time.Duration
+	deadline time.Time
+	ok       bool
+}
+
+// NewBudget returns a budget of the time remaining until the deadline of the context.
+// The sub-budgets are not limited if the context has no deadline.
+func NewBudget(ctx context.Context) *Budget {
+	b := &Budget{ctx: ctx}
+	b.deadline, b.ok = ctx.Deadline()
+
+	if b.ok {
+		b.total = time.Until(b.deadline)
+	}
+
+	return b
+}
+
+// Total returns the time of the budget, zero if the context has no deadline.
+func (b *Budget) Total() time.Duration {
+	return b.total
+}
+
+// Remaining returns the time remaining until the deadline, zero if the context has no deadline.
+func (b *Budget) Remaining() time.Duration {
+	if !b.ok {
+		return 0
+	}
+
+	return max(time.Until(b.deadline), 0)
+}
+
+// WithFraction returns a context with a deadline of the fraction of the total budget from now,
+// limited by the deadline of the budget. The fraction is clamped to [0, 1].
+func (b *Budget) WithFraction(fraction float64) (context.Context, context.CancelFunc) {
+	if !b.ok {
+		return context.WithCancel(b.ctx)
+	}
+
+	fraction = min(max(fraction, 0), 1)
+
+	return context.WithTimeout(b.ctx, time.Duration(float64(b.total)*fraction))
+}
@@ -0,0 +1,64 @@
+package ctx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	parent, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	b := NewBudget(parent)
+	assert.InDelta(t, 10*time.Second, b.Total(), float64(time.Second))
+	assert.LessOrEqual(t, b.Remaining(), b.Total())
+
+	db, cancelDB := b.WithFraction(0.3)
+	defer cancelDB()
+
+	deadline, ok := db.Deadline()
+	require.True(t, ok)
+	assert.InDelta(t, 3*time.Second, time.Until(deadline), float64(time.Second))
+
+	all, cancelAll := b.WithFraction(2)
+	defer cancelAll()
+
+	deadline, ok = all.Deadline()
+	require.True(t, ok)
+
+	parentDeadline, _ := parent.Deadline()
+	assert.False(t, deadline.After(parentDeadline))
+}
+
+func TestBudgetNoDeadline(t *testing.T) {
+	b := NewBudget(t.Context())
+	assert.Zero(t, b.Total())
+	assert.Zero(t, b.Remaining())
+
+	sub, cancel := b.WithFraction(0.5)
+	defer cancel()
+
+	_, ok := sub.Deadline()
+	assert.False(t, ok)
+}
+
+func TestDetach(t *testing.T) {
+	key := New("tenant", "")
+
+	parent, cancel := context.WithCancel(key.WithValue(t.Context(), "acme"))
+
+	detached, cancelDetached := Detach(parent, time.Second)
+	defer cancelDetached()
+
+	cancel()
+
+	require.NoError(t, detached.Err())
+	assert.Equal(t, "acme", key.Value(detached))
+
+	_, ok := detached.Deadline()
+	assert.True(t, ok)
+}
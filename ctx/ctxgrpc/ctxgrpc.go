@@ -0,0 +1,92 @@
+// Package ctxgrpc propagates the keys of a ctx.Registry as gRPC metadata.
+package ctxgrpc
+
+import (
+	"context"
+
+	pkgctx "github.com/katallaxie/pkg/ctx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataCarrier is a carrier for gRPC metadata.
+type MetadataCarrier metadata.MD
+
+// Get returns the first value of the metadata.
+func (c MetadataCarrier) Get(name string) string {
+	if v := metadata.MD(c).Get(name); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+// Set sets the value of the metadata.
+func (c MetadataCarrier) Set(name, value string) { metadata.MD(c).Set(name, value) }
+
+// UnaryClientInterceptor returns an interceptor setting the values of the registered keys
+// in the context of the calls as outgoing metadata, values which cannot be encoded are skipped.
+func UnaryClientInterceptor(r *pkgctx.Registry) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx, r), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns an interceptor setting the values of the registered keys
+// in the context of the streams as outgoing metadata, values which cannot be encoded are skipped.
+func StreamClientInterceptor(r *pkgctx.Registry) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx, r), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor returns an interceptor adding the values of the registered keys
+// from the incoming metadata to the context of the calls. Invalid values are skipped.
+func UnaryServerInterceptor(r *pkgctx.Registry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incoming(ctx, r), req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor adding the values of the registered keys
+// from the incoming metadata to the context of the streams. Invalid values are skipped.
+func StreamServerInterceptor(r *pkgctx.Registry) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incoming(ss.Context(), r)})
+	}
+}
+
+func outgoing(ctx context.Context, r *pkgctx.Registry) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+
+	// values which cannot be encoded are skipped, as they are skipped by Extract.
+	_ = r.Inject(ctx, MetadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func incoming(ctx context.Context, r *pkgctx.Registry) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	ctx, _ = r.Extract(ctx, MetadataCarrier(md))
+
+	return ctx
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context with the extracted values.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
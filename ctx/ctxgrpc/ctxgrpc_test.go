@@ -0,0 +1,82 @@
+package ctxgrpc_test
+
+import (
+	"context"
+	"testing"
+
+	pkgctx "github.com/katallaxie/pkg/ctx"
+	"github.com/katallaxie/pkg/ctx/ctxgrpc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func testRegistry() (*pkgctx.Registry, pkgctx.Key[string]) {
+	tenant := pkgctx.New("tenant", "")
+
+	r := pkgctx.NewRegistry()
+	pkgctx.Register(r, tenant, "X-Tenant-ID", pkgctx.StringCodec{})
+
+	return r, tenant
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	r, tenant := testRegistry()
+
+	var md metadata.MD
+
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := metadata.AppendToOutgoingContext(tenant.WithValue(t.Context(), "acme"), "x-other", "value")
+	require.NoError(t, ctxgrpc.UnaryClientInterceptor(r)(ctx, "/test", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"acme"}, md.Get("x-tenant-id"))
+	assert.Equal(t, []string{"value"}, md.Get("x-other"))
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return tenant.Value(ctx), nil
+	}
+
+	res, err := ctxgrpc.UnaryServerInterceptor(r)(metadata.NewIncomingContext(t.Context(), md), nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", res)
+
+	pkgctx.Register(r, pkgctx.RequestID, pkgctx.RequestIDHeader, pkgctx.RequestIDCodec{})
+
+	require.NoError(t, ctxgrpc.UnaryClientInterceptor(r)(pkgctx.RequestID.WithValue(ctx, "foo bar"), "/test", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"acme"}, md.Get("x-tenant-id"))
+	assert.Empty(t, md.Get(pkgctx.RequestIDHeader))
+
+	md = metadata.Pairs("x-tenant-id", "acme\r\n")
+
+	res, err = ctxgrpc.UnaryServerInterceptor(r)(metadata.NewIncomingContext(t.Context(), md), nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	r, tenant := testRegistry()
+
+	md := metadata.Pairs("x-tenant-id", "acme")
+	ss := &testStream{ctx: metadata.NewIncomingContext(t.Context(), md)}
+
+	var value string
+
+	err := ctxgrpc.StreamServerInterceptor(r)(nil, ss, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+		value = tenant.Value(stream.Context())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", value)
+}
@@ -0,0 +1,55 @@
+// Package ctxhttp propagates the keys of a ctx.Registry as HTTP headers.
+package ctxhttp
+
+import (
+	"net/http"
+
+	"github.com/katallaxie/pkg/ctx"
+)
+
+// HeaderCarrier is a carrier for HTTP headers.
+type HeaderCarrier http.Header
+
+// Get returns the value of the header.
+func (c HeaderCarrier) Get(name string) string { return http.Header(c).Get(name) }
+
+// Set sets the value of the header.
+func (c HeaderCarrier) Set(name, value string) { http.Header(c).Set(name, value) }
+
+type transport struct {
+	registry *ctx.Registry
+	base     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	// values which cannot be encoded are skipped, as they are skipped by Extract.
+	_ = t.registry.Inject(req.Context(), HeaderCarrier(req.Header))
+
+	return t.base.RoundTrip(req)
+}
+
+// Transport returns a http.RoundTripper setting the values of the registered keys
+// in the context of the requests as headers, values which cannot be encoded are skipped.
+// The http.DefaultTransport is used if base is nil.
+func Transport(r *ctx.Registry, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{registry: r, base: base}
+}
+
+// Middleware returns a middleware adding the values of the registered keys
+// from the headers of the requests to their context. Invalid values are skipped.
+func Middleware(r *ctx.Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			c, _ := r.Extract(req.Context(), HeaderCarrier(req.Header))
+
+			next.ServeHTTP(w, req.WithContext(c))
+		})
+	}
+}
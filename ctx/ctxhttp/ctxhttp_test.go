@@ -0,0 +1,87 @@
+package ctxhttp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/katallaxie/pkg/ctx"
+	"github.com/katallaxie/pkg/ctx/ctxhttp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportMiddleware(t *testing.T) {
+	tenant := ctx.New("tenant", "")
+
+	r := ctx.NewRegistry()
+	ctx.Register(r, tenant, "X-Tenant-ID", ctx.StringCodec{})
+
+	srv := httptest.NewServer(ctxhttp.Middleware(r)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(tenant.Value(req.Context())))
+	})))
+	defer srv.Close()
+
+	client := &http.Client{Transport: ctxhttp.Transport(r, nil)}
+
+	req, err := http.NewRequestWithContext(tenant.WithValue(t.Context(), "acme"), http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", string(body))
+	assert.Empty(t, req.Header.Get("X-Tenant-ID"))
+}
+
+func TestTransportInvalid(t *testing.T) {
+	tenant := ctx.New("tenant", "")
+
+	r := ctx.NewRegistry()
+	ctx.Register(r, tenant, "X-Tenant-ID", ctx.StringCodec{})
+	ctx.Register(r, ctx.RequestID, ctx.RequestIDHeader, ctx.RequestIDCodec{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		_, _ = w.Write([]byte(req.Header.Get("X-Tenant-ID") + "|" + req.Header.Get(ctx.RequestIDHeader) + "|" + string(body)))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: ctxhttp.Transport(r, nil)}
+
+	c := ctx.RequestID.WithValue(tenant.WithValue(t.Context(), "acme"), "foo bar")
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, srv.URL, strings.NewReader("hello"))
+	require.NoError(t, err)
+
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "acme||hello", string(body))
+}
+
+func TestMiddlewareInvalid(t *testing.T) {
+	var id string
+
+	h := ctxhttp.Middleware(ctx.DefaultRegistry)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id = ctx.RequestID.Value(req.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(ctx.RequestIDHeader, "foo bar")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Empty(t, id)
+
+	req.Header.Set(ctx.RequestIDHeader, "foo")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "foo", id)
+}
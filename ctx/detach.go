@@ -0,0 +1,19 @@
+package ctx
+
+import (
+	"context"
+	"time"
+)
+
+// Detach returns a context with the values of the parent, including the values of Keys,
+// which is not canceled with the parent, e.g. for background cleanup after a request.
+// The context is canceled after the timeout, if the timeout is positive.
+func Detach(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(parent)
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
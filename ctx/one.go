@@ -15,26 +15,31 @@ var ErrCanceled = errors.New("context canceled")
 type OneContext struct {
 	ctx        context.Context
 	ctxs       []context.Context
-	done       chan struct{}
 	err        error
 	errMutex   sync.RWMutex
 	cancelFunc context.CancelFunc
 	cancelCtx  context.Context
+
+	// causeCtx is done with the context and records the cause for context.Cause.
+	causeCtx    context.Context
+	causeCancel context.CancelCauseFunc
 }
 
 var _ context.Context = (*OneContext)(nil)
 
 // Merge merges the given contexts into a single context that will be done when any of the given contexts is done.
+// The cause of the context done first is returned by context.Cause, or ErrCanceled when the CancelFunc is called.
 func Merge(ctx context.Context, ctxs ...context.Context) (context.Context, context.CancelFunc) {
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 
 	o := &OneContext{
-		done:       make(chan struct{}),
 		ctx:        ctx,
 		ctxs:       ctxs,
 		cancelCtx:  cancelCtx,
 		cancelFunc: cancelFunc,
 	}
+	o.causeCtx, o.causeCancel = context.WithCancelCause(values{o})
+
 	go o.run()
 
 	return o, cancelFunc
@@ -61,7 +66,7 @@ func (o *OneContext) Deadline() (time.Time, bool) {
 
 // Done returns a channel for cancellation.
 func (o *OneContext) Done() <-chan struct{} {
-	return o.done
+	return o.causeCtx.Done()
 }
 
 // Err returns the first error raised by the contexts, otherwise a nil error.
@@ -74,6 +79,23 @@ func (o *OneContext) Err() error {
 
 // Value returns the value associated with the key from one of the contexts.
 func (o *OneContext) Value(key interface{}) interface{} {
+	return o.causeCtx.Value(key)
+}
+
+// values is a context without cancellation returning the values of the merged contexts.
+type values struct {
+	o *OneContext
+}
+
+func (values) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (values) Done() <-chan struct{} { return nil }
+
+func (values) Err() error { return nil }
+
+func (v values) Value(key any) any {
+	o := v.o
+
 	if value := o.ctx.Value(key); value != nil {
 		return value
 	}
@@ -99,11 +121,11 @@ func (o *OneContext) run() {
 func (o *OneContext) runTwoContexts(ctx1, ctx2 context.Context) {
 	select {
 	case <-o.cancelCtx.Done():
-		o.cancel(ErrCanceled)
+		o.cancel(ErrCanceled, ErrCanceled)
 	case <-ctx1.Done():
-		o.cancel(o.ctx.Err())
+		o.cancel(ctx1.Err(), context.Cause(ctx1))
 	case <-ctx2.Done():
-		o.cancel(o.ctxs[0].Err())
+		o.cancel(ctx2.Err(), context.Cause(ctx2))
 	}
 }
 
@@ -118,19 +140,20 @@ func (o *OneContext) runMultipleContexts() {
 	chosen, _, _ := reflect.Select(cases)
 	switch chosen {
 	case 0:
-		o.cancel(ErrCanceled)
+		o.cancel(ErrCanceled, ErrCanceled)
 	case 1:
-		o.cancel(o.ctx.Err())
+		o.cancel(o.ctx.Err(), context.Cause(o.ctx))
 	default:
-		o.cancel(o.ctxs[chosen-2].Err())
+		ctx := o.ctxs[chosen-2]
+		o.cancel(ctx.Err(), context.Cause(ctx))
 	}
 }
 
-func (o *OneContext) cancel(err error) {
+func (o *OneContext) cancel(err, cause error) {
 	o.errMutex.Lock()
 	o.err = err
 	o.errMutex.Unlock()
 
-	close(o.done)
+	o.causeCancel(cause)
 	o.cancelFunc()
 }
@@ -2,6 +2,7 @@ package ctx
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -182,3 +183,38 @@ func Test_Cancel_Multiple_Cancel(t *testing.T) {
 	assert.Error(t, ctx.Err())
 	assert.Equal(t, ErrCanceled, ctx.Err())
 }
+
+func Test_Merge_Cause(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errShutdown := errors.New("shutdown")
+
+	ctx1 := t.Context()
+	ctx2, cancel2 := context.WithCancelCause(t.Context())
+	ctx3 := t.Context()
+
+	ctx, cancel := Merge(ctx1, ctx2, ctx3)
+	defer cancel()
+
+	assert.NoError(t, context.Cause(ctx))
+
+	cancel2(errShutdown)
+	assert.True(t, eventually(ctx.Done()))
+	assert.Equal(t, context.Canceled, ctx.Err())
+	assert.ErrorIs(t, context.Cause(ctx), errShutdown)
+
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	assert.ErrorIs(t, context.Cause(child), errShutdown)
+}
+
+func Test_Merge_Cause_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := Merge(t.Context(), t.Context())
+
+	cancel()
+	assert.True(t, eventually(ctx.Done()))
+	assert.ErrorIs(t, context.Cause(ctx), ErrCanceled)
+}
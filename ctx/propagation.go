@@ -0,0 +1,195 @@
+package ctx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+// MaxValueLength is the maximum length of an extracted value.
+const MaxValueLength = 4096
+
+// ErrInvalidValue is returned when an extracted value is too long or contains control characters.
+var ErrInvalidValue = errors.New("ctx: invalid value")
+
+// Codec encodes and decodes the value of a key to propagate it.
+type Codec[Value any] interface {
+	// Encode encodes the value.
+	Encode(Value) (string, error)
+	// Decode decodes the value.
+	Decode(string) (Value, error)
+}
+
+// StringCodec is a codec for string values.
+type StringCodec struct{}
+
+// Encode encodes the value.
+func (StringCodec) Encode(v string) (string, error) { return v, nil }
+
+// Decode decodes the value.
+func (StringCodec) Decode(s string) (string, error) { return s, nil }
+
+// JSONCodec is a codec encoding the values as JSON.
+type JSONCodec[Value any] struct{}
+
+// Encode encodes the value.
+func (JSONCodec[Value]) Encode(v Value) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// Decode decodes the value.
+func (JSONCodec[Value]) Decode(s string) (Value, error) {
+	var v Value
+	err := json.Unmarshal([]byte(s), &v)
+
+	return v, err
+}
+
+// Carrier is carrying the propagated values, e.g. HTTP headers or gRPC metadata.
+type Carrier interface {
+	// Get returns the value of the field.
+	Get(name string) string
+	// Set sets the value of the field.
+	Set(name, value string)
+}
+
+type field struct {
+	name    string
+	inject  func(ctx context.Context) (string, bool, error)
+	extract func(ctx context.Context, value string) (context.Context, error)
+}
+
+// Registry is a registry of the keys propagated across process boundaries.
+type Registry struct {
+	mu     sync.RWMutex
+	fields []field
+}
+
+// NewRegistry returns a new registry.
+func NewRegistry() *Registry {
+	return new(Registry)
+}
+
+// DefaultRegistry is the registry used by Propagate,
+// it propagates the RequestID as the RequestIDHeader.
+var DefaultRegistry = defaultRegistry()
+
+func defaultRegistry() *Registry {
+	r := NewRegistry()
+	Register(r, RequestID, RequestIDHeader, RequestIDCodec{})
+
+	return r
+}
+
+// Register is registering the key to be propagated as the field with the codec,
+// e.g. the HTTP header or the gRPC metadata key.
+func Register[Value any](r *Registry, key Key[Value], name string, codec Codec[Value]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fields = append(r.fields, field{
+		name: name,
+		inject: func(ctx context.Context) (string, bool, error) {
+			v, ok := key.ValueOk(ctx)
+			if !ok {
+				return "", false, nil
+			}
+
+			s, err := codec.Encode(v)
+
+			return s, true, err
+		},
+		extract: func(ctx context.Context, s string) (context.Context, error) {
+			v, err := codec.Decode(s)
+			if err != nil {
+				return ctx, err
+			}
+
+			return key.WithValue(ctx, v), nil
+		},
+	})
+}
+
+// Propagate is registering the key in the default registry.
+func Propagate[Value any](key Key[Value], name string, codec Codec[Value]) {
+	Register(DefaultRegistry, key, name, codec)
+}
+
+// Inject is setting the values of the registered keys in the context on the carrier,
+// values which cannot be encoded are skipped and returned as error.
+func (r *Registry) Inject(ctx context.Context, c Carrier) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+
+	for _, f := range r.fields {
+		v, ok, err := f.inject(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("inject %s: %w", f.name, err))
+			continue
+		}
+
+		if ok {
+			c.Set(f.name, v)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Extract returns a context with the values of the registered keys on the carrier,
+// the values are untrusted input: values which are longer than MaxValueLength,
+// contain control characters or cannot be decoded are skipped and returned as error.
+func (r *Registry) Extract(ctx context.Context, c Carrier) (context.Context, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+
+	for _, f := range r.fields {
+		v := c.Get(f.name)
+		if v == "" {
+			continue
+		}
+
+		if !validValue(v) {
+			errs = append(errs, fmt.Errorf("extract %s: %w", f.name, ErrInvalidValue))
+			continue
+		}
+
+		next, err := f.extract(ctx, v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("extract %s: %w", f.name, err))
+			continue
+		}
+
+		ctx = next
+	}
+
+	return ctx, errors.Join(errs...)
+}
+
+// validValue reports whether the value is valid UTF-8 without control characters
+// and not longer than MaxValueLength.
+func validValue(v string) bool {
+	if len(v) > MaxValueLength || !utf8.ValidString(v) {
+		return false
+	}
+
+	for _, r := range v {
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+package ctx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapCarrier map[string]string
+
+func (c mapCarrier) Get(name string) string { return c[name] }
+
+func (c mapCarrier) Set(name, value string) { c[name] = value }
+
+type user struct {
+	ID    int    `json:"id"`
+	Admin bool   `json:"admin"`
+	Name  string `json:"name"`
+}
+
+func testRegistry() (*Registry, Key[string], Key[user]) {
+	tenant := New("tenant", "")
+	usr := New("user", user{})
+
+	r := NewRegistry()
+	Register(r, tenant, "X-Tenant-ID", StringCodec{})
+	Register(r, usr, "X-User", JSONCodec[user]{})
+
+	return r, tenant, usr
+}
+
+func TestRegistryInjectExtract(t *testing.T) {
+	r, tenant, usr := testRegistry()
+
+	ctx := tenant.WithValue(t.Context(), "acme")
+
+	carrier := mapCarrier{}
+	require.NoError(t, r.Inject(ctx, carrier))
+	assert.Equal(t, "acme", carrier.Get("X-Tenant-ID"))
+	assert.Empty(t, carrier.Get("X-User"))
+
+	carrier.Set("X-User", `{"id":1,"admin":true,"name":"alice"}`)
+
+	extracted, err := r.Extract(t.Context(), carrier)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", tenant.Value(extracted))
+	assert.Equal(t, user{ID: 1, Admin: true, Name: "alice"}, usr.Value(extracted))
+}
+
+func TestRegistryExtractInvalid(t *testing.T) {
+	r, tenant, usr := testRegistry()
+
+	carrier := mapCarrier{"X-Tenant-ID": "acme", "X-User": "{"}
+
+	ctx, err := r.Extract(t.Context(), carrier)
+	require.Error(t, err)
+	assert.Equal(t, "acme", tenant.Value(ctx))
+	assert.False(t, usr.Has(ctx))
+}
+
+func TestRegistryExtractUntrusted(t *testing.T) {
+	r, tenant, _ := testRegistry()
+
+	for _, v := range []string{"acme\r\nX-Admin: true", "acme\x00", "\xff", strings.Repeat("a", MaxValueLength+1)} {
+		ctx, err := r.Extract(t.Context(), mapCarrier{"X-Tenant-ID": v})
+		require.ErrorIs(t, err, ErrInvalidValue)
+		assert.False(t, tenant.Has(ctx))
+	}
+}
+
+func TestDefaultRegistryRequestID(t *testing.T) {
+	carrier := mapCarrier{}
+	require.NoError(t, DefaultRegistry.Inject(RequestID.WithValue(t.Context(), "42"), carrier))
+	assert.Equal(t, "42", carrier.Get(RequestIDHeader))
+
+	ctx, err := DefaultRegistry.Extract(t.Context(), carrier)
+	require.NoError(t, err)
+	assert.Equal(t, "42", RequestID.Value(ctx))
+
+	ctx, err = DefaultRegistry.Extract(t.Context(), mapCarrier{RequestIDHeader: "foo bar"})
+	require.ErrorIs(t, err, ErrInvalidRequestID)
+	assert.False(t, RequestID.Has(ctx))
+}
+
+func TestValidRequestID(t *testing.T) {
+	assert.True(t, ValidRequestID("01ARZ3NDEKTSV4RRFFQ69G5FAV"))
+	assert.True(t, ValidRequestID("a-b_c.d"))
+	assert.False(t, ValidRequestID(""))
+	assert.False(t, ValidRequestID("foo\r\nbar"))
+	assert.False(t, ValidRequestID(strings.Repeat("a", MaxRequestIDLength+1)))
+}
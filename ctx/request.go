@@ -0,0 +1,58 @@
+package ctx
+
+import (
+	"errors"
+)
+
+const (
+	// RequestIDHeader is the header to carry the request ID.
+	RequestIDHeader = "X-Request-ID"
+	// MaxRequestIDLength is the maximum length of an accepted request ID.
+	MaxRequestIDLength = 128
+)
+
+// ErrInvalidRequestID is returned when a propagated request ID is not valid.
+var ErrInvalidRequestID = errors.New("ctx: invalid request ID")
+
+// RequestID is the key of the request ID, it is set by the request ID middleware of the server,
+// added to the fields of logx and propagated by the DefaultRegistry.
+var RequestID = New("request_id", "")
+
+// ValidRequestID reports whether the request ID is short
+// and consists of letters, digits, '-', '_' and '.' only.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+
+	for _, c := range []byte(id) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// RequestIDCodec is a codec for request IDs, it rejects invalid request IDs.
+type RequestIDCodec struct{}
+
+// Encode encodes the request ID.
+func (RequestIDCodec) Encode(id string) (string, error) {
+	if !ValidRequestID(id) {
+		return "", ErrInvalidRequestID
+	}
+
+	return id, nil
+}
+
+// Decode decodes the request ID.
+func (RequestIDCodec) Decode(s string) (string, error) {
+	if !ValidRequestID(s) {
+		return "", ErrInvalidRequestID
+	}
+
+	return s, nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/katallaxie/pkg/async"
@@ -22,13 +23,21 @@ func main() {
 		return "", fmt.Errorf("this is a main error")
 	}
 
-	async.New(fn).Then(func(s string) {
-		log.Print(s)
+	hello := async.Then(async.New(fn), func(s string) (string, error) {
+		return strings.ToUpper(s), nil
+	}).Finally(func() {
+		log.Print("done")
 	})
 
-	async.New(errFn).Then(func(s string) {
-		log.Print(s)
-	}).Catch(func(err error) {
+	recovered := async.New(errFn).Catch(func(err error) (string, error) {
 		log.Print(fmt.Errorf("error: %w", err))
+		return "world", nil
 	})
+
+	v, err := async.All(hello, recovered).Await()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Print(strings.Join(v, " "))
 }
@@ -6,17 +6,19 @@ import (
 	"log"
 	"time"
 
+	"github.com/katallaxie/pkg/logx"
 	"github.com/katallaxie/pkg/server"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-type srv struct{}
+type srv struct {
+	app *fiber.App
+}
 
 func (s *srv) Start(ctx context.Context, ready server.ReadyFunc, run server.RunFunc) func() error {
 	return func() error {
-		app := fiber.New()
-		app.Get("/", func(c *fiber.Ctx) error {
+		s.app.Get("/", func(c *fiber.Ctx) error {
 			return c.SendString("Hello, World!")
 		})
 
@@ -31,7 +33,7 @@ func (s *srv) Start(ctx context.Context, ready server.ReadyFunc, run server.RunF
 
 		ready()
 
-		err := app.Listen(":3000")
+		err := s.app.Listen(":3000")
 		if err != nil {
 			return err
 		}
@@ -40,15 +42,23 @@ func (s *srv) Start(ctx context.Context, ready server.ReadyFunc, run server.RunF
 	}
 }
 
+func (s *srv) Stop(ctx context.Context) error {
+	return s.app.ShutdownWithContext(ctx)
+}
+
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	s, _ := server.WithContext(ctx)
+	s, _ := server.WithContext(ctx, server.WithHooks(server.LogHooks()))
 	s.SetLimit(3)
 
-	s.Listen(&srv{}, true)
-	s.Listen(server.NewDebug(server.WithPprof()), true)
+	s.Listen(&srv{app: fiber.New()}, true)
+	s.Listen(server.NewDebug(
+		server.WithPprof(),
+		server.WithHealth(s.Health()),
+		server.WithRoute("/debug/log/levels", logx.LevelHandler()),
+	), true)
 
 	log.Printf("starting %s", server.Service.Name())
 	serverErr := &server.ServerError{}
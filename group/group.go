@@ -2,10 +2,37 @@ package group
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
 )
 
+// PanicError is returned when a goroutine in the group panics.
+type PanicError struct {
+	// Name is the name of the goroutine, empty if added without a name.
+	Name string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error implements the error interface, the stack trace is not included.
+func (e *PanicError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("panic: %v", e.Value)
+	}
+
+	return fmt.Sprintf("%s: panic: %v", e.Name, e.Value)
+}
+
+// Unwrap returns the value passed to panic if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
 // Group manages the lifetime of a set of goroutines from a common context.
 // The first goroutine in the group to return will cause the context to be canceled,
 // terminating the remaining goroutines.
@@ -14,11 +41,18 @@ type Group struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	done   sync.WaitGroup
+	sem    chan struct{}
+
+	cancelOnError bool
+	joinErrors    bool
 
 	initOnce sync.Once
 
 	errOnce sync.Once
 	err     error
+
+	mu   sync.Mutex
+	errs []error
 }
 
 // Opt is an option for the routine group.
@@ -31,6 +65,32 @@ func WithContext(ctx context.Context) Opt {
 	}
 }
 
+// WithLimit limits the number of goroutines running at the same time,
+// Add blocks until a goroutine returns when the limit is reached.
+func WithLimit(n int) Opt {
+	return func(g *Group) {
+		if n > 0 {
+			g.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithCancelOnError only cancels the context when a goroutine returns an error or panics,
+// goroutines returning nil do not terminate the remaining goroutines.
+func WithCancelOnError() Opt {
+	return func(g *Group) {
+		g.cancelOnError = true
+	}
+}
+
+// WithJoinErrors returns all the errors of the goroutines joined by Wait,
+// instead of only the first error.
+func WithJoinErrors() Opt {
+	return func(g *Group) {
+		g.joinErrors = true
+	}
+}
+
 // New creates a new group.
 func New(opts ...Opt) *Group {
 	g := new(Group)
@@ -53,32 +113,83 @@ func (g *Group) init() {
 
 // Add is adding a new goroutine to the group.
 func (g *Group) Add(fn func(context.Context) error) {
+	g.AddNamed("", fn)
+}
+
+// AddNamed is adding a new goroutine to the group,
+// the name is prefixed to the error or the panic of the goroutine.
+func (g *Group) AddNamed(name string, fn func(context.Context) error) {
 	g.initOnce.Do(g.init)
+
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
 	g.done.Add(1)
 
 	go func() {
 		defer g.done.Done()
-		defer g.cancel()
-		defer func() {
-			if r := recover(); r != nil {
-				g.errOnce.Do(func() {
-					if err, ok := r.(error); ok {
-						g.err = err
-					} else {
-						g.err = fmt.Errorf("panic: %v", r)
-					}
-				})
-			}
-		}()
-		if err := fn(g.ctx); err != nil {
-			g.errOnce.Do(func() { g.err = err })
+
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		err := g.run(name, fn)
+		if err != nil {
+			g.fail(err)
+		}
+
+		if err != nil || !g.cancelOnError {
+			g.cancel()
 		}
 	}()
 }
 
+func (g *Group) run(name string, fn func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Name: name, Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	if err := fn(g.ctx); err != nil {
+		if name != "" {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (g *Group) fail(err error) {
+	if g.joinErrors {
+		g.mu.Lock()
+		g.errs = append(g.errs, err)
+		g.mu.Unlock()
+
+		return
+	}
+
+	g.errOnce.Do(func() { g.err = err })
+}
+
 // Wait is a blocking call that waits for all goroutines to exit.
 func (g *Group) Wait() error {
 	g.done.Wait()
+
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	if g.joinErrors {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		return errors.Join(g.errs...)
+	}
+
 	g.errOnce.Do(func() {
 		// noop, required to synchronise on the errOnce mutex.
 	})
@@ -0,0 +1,146 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupCancelOnReturn(t *testing.T) {
+	g := New()
+
+	g.Add(func(ctx context.Context) error {
+		return nil
+	})
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	require.NoError(t, g.Wait())
+}
+
+func TestGroupFirstError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	g := New()
+
+	g.Add(func(ctx context.Context) error {
+		return errFailed
+	})
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	require.ErrorIs(t, g.Wait(), errFailed)
+}
+
+func TestGroupCancelOnError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	g := New(WithCancelOnError())
+
+	finished := make(chan struct{})
+
+	g.Add(func(ctx context.Context) error {
+		return nil
+	})
+	g.Add(func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		close(finished)
+
+		return nil
+	})
+
+	require.NoError(t, g.Wait())
+	<-finished
+
+	g = New(WithCancelOnError())
+
+	g.Add(func(ctx context.Context) error {
+		return errFailed
+	})
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	require.ErrorIs(t, g.Wait(), errFailed)
+}
+
+func TestGroupJoinErrors(t *testing.T) {
+	errFailed := errors.New("failed")
+	errOther := errors.New("other")
+
+	g := New(WithCancelOnError(), WithJoinErrors())
+
+	g.Add(func(ctx context.Context) error {
+		return errFailed
+	})
+	g.AddNamed("other", func(ctx context.Context) error {
+		<-ctx.Done()
+		return errOther
+	})
+
+	err := g.Wait()
+	require.ErrorIs(t, err, errFailed)
+	require.ErrorIs(t, err, errOther)
+	assert.Contains(t, err.Error(), "other: other")
+}
+
+func TestGroupLimit(t *testing.T) {
+	g := New(WithLimit(2), WithCancelOnError())
+
+	var running, peak atomic.Int32
+
+	for range 10 {
+		g.Add(func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			return nil
+		})
+	}
+
+	require.NoError(t, g.Wait())
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestGroupPanic(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	g := New()
+
+	g.AddNamed("worker", func(ctx context.Context) error {
+		panic(errFailed)
+	})
+
+	err := g.Wait()
+	require.ErrorIs(t, err, errFailed)
+
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "worker", panicErr.Name)
+	assert.Equal(t, "worker: panic: failed", panicErr.Error())
+	assert.Contains(t, string(panicErr.Stack), "TestGroupPanic")
+}
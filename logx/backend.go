@@ -0,0 +1,86 @@
+package logx
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Backend is writing the statements of a logger, e.g. to zap or log/slog.
+type Backend interface {
+	// Enabled reports whether statements of the level are written.
+	Enabled(level Level) bool
+	// Log is writing the statement with the fields at the level.
+	Log(level Level, msg string, keysAndValues ...interface{})
+}
+
+var _ Backend = (*zapBackend)(nil)
+
+type zapBackend struct {
+	log *zap.SugaredLogger
+}
+
+// NewZapBackend returns a backend writing to the zap logger.
+func NewZapBackend(l *zap.Logger) Backend {
+	return &zapBackend{log: l.Sugar()}
+}
+
+// Enabled reports whether statements of the level are written.
+func (b *zapBackend) Enabled(level Level) bool {
+	return b.log.Level().Enabled(zapcore.Level(level))
+}
+
+// Log is writing the statement with the fields at the level.
+func (b *zapBackend) Log(level Level, msg string, keysAndValues ...interface{}) {
+	b.log.Logw(zapcore.Level(level), msg, keysAndValues...)
+}
+
+var _ Backend = (*slogBackend)(nil)
+
+type slogBackend struct {
+	log *slog.Logger
+}
+
+// NewSlogBackend returns a backend writing to the slog logger,
+// the panic and fatal levels are written as errors.
+func NewSlogBackend(l *slog.Logger) Backend {
+	return &slogBackend{log: l}
+}
+
+// Enabled reports whether statements of the level are written.
+func (b *slogBackend) Enabled(level Level) bool {
+	return b.log.Enabled(context.Background(), toSlog(level))
+}
+
+// Log is writing the statement with the fields at the level.
+func (b *slogBackend) Log(level Level, msg string, keysAndValues ...interface{}) {
+	b.log.Log(context.Background(), toSlog(level), msg, keysAndValues...)
+}
+
+func toSlog(level Level) slog.Level {
+	switch {
+	case level <= DebugLevel:
+		return slog.LevelDebug
+	case level == InfoLevel:
+		return slog.LevelInfo
+	case level == WarnLevel:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+func fromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
@@ -0,0 +1,154 @@
+package logx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	pkgctx "github.com/katallaxie/pkg/ctx"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type fieldsKey struct{}
+
+// WithFields returns a context with the fields added to the fields of the parent context.
+func WithFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+
+	return context.WithValue(ctx, fieldsKey{}, slices.Concat(fields, keysAndValues))
+}
+
+// Fields returns the fields of the context, including the request ID
+// of the ctx.RequestID key and the OpenTelemetry trace and span IDs when present.
+func Fields(ctx context.Context) []interface{} {
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+
+	if id, ok := pkgctx.RequestID.ValueOk(ctx); ok {
+		fields = append(slices.Clip(fields), "request_id", id)
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(slices.Clip(fields), "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+
+	return fields
+}
+
+// FromContext returns a logger with the global level adding the fields of the context.
+func FromContext(ctx context.Context) Logger {
+	return With(root, Fields(ctx)...)
+}
+
+// With returns a logger adding the fields to every statement,
+// the formatted statements are logged with the fields as well.
+func With(l Logger, keysAndValues ...interface{}) Logger {
+	if len(keysAndValues) == 0 {
+		return l
+	}
+
+	return &fieldsLogger{Logger: l, fields: slices.Clip(keysAndValues)}
+}
+
+var _ Logger = (*fieldsLogger)(nil)
+
+type fieldsLogger struct {
+	Logger
+	fields []interface{}
+}
+
+// Enabled reports whether statements of the level are written.
+func (l *fieldsLogger) Enabled(level Level) bool {
+	return enabled(l.Logger, level)
+}
+
+func (l *fieldsLogger) write(level Level, id string, msg func() string, keysAndValues ...interface{}) {
+	writeTo(l.Logger, level, id, msg, slices.Concat(l.fields, keysAndValues)...)
+}
+
+func (l *fieldsLogger) logf(level Level, format string, v []interface{}) {
+	l.write(level, format, func() string { return fmt.Sprintf(format, v...) })
+}
+
+func (l *fieldsLogger) log(level Level, msg string, keysAndValues []interface{}) {
+	l.write(level, msg, func() string { return msg }, keysAndValues...)
+}
+
+// Noticef is logging a notice statement.
+func (l *fieldsLogger) Noticef(format string, v ...interface{}) {
+	l.logf(InfoLevel, format, v)
+}
+
+// Infof is logging an info statement.
+func (l *fieldsLogger) Infof(format string, v ...interface{}) {
+	l.logf(InfoLevel, format, v)
+}
+
+// Warnf is logging a warning statement.
+func (l *fieldsLogger) Warnf(format string, v ...interface{}) {
+	l.logf(WarnLevel, format, v)
+}
+
+// Fatalf is logging a fatal error.
+func (l *fieldsLogger) Fatalf(format string, v ...interface{}) {
+	l.logf(FatalLevel, format, v)
+}
+
+// Errorf is logging an error.
+func (l *fieldsLogger) Errorf(format string, v ...interface{}) {
+	l.logf(ErrorLevel, format, v)
+}
+
+// Debugf is logging a debug statement.
+func (l *fieldsLogger) Debugf(format string, v ...interface{}) {
+	l.logf(DebugLevel, format, v)
+}
+
+// Tracef is logging a trace statement.
+func (l *fieldsLogger) Tracef(format string, v ...interface{}) {
+	l.logf(DebugLevel, format, v)
+}
+
+// Panicf is logging a panic statement.
+func (l *fieldsLogger) Panicf(format string, v ...interface{}) {
+	l.logf(PanicLevel, format, v)
+}
+
+// Printf is logging a printf statement.
+func (l *fieldsLogger) Printf(format string, v ...interface{}) {
+	l.logf(InfoLevel, format, v)
+}
+
+// Debugw is logging a debug statement with context.
+func (l *fieldsLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	l.log(DebugLevel, msg, keysAndValues)
+}
+
+// Infow is logging an info statement with context.
+func (l *fieldsLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.log(InfoLevel, msg, keysAndValues)
+}
+
+// Warnw is logging a warning statement with context.
+func (l *fieldsLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.log(WarnLevel, msg, keysAndValues)
+}
+
+// Errorw is logging an error statement with context.
+func (l *fieldsLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.log(ErrorLevel, msg, keysAndValues)
+}
+
+// DPanicw is logging a debug panic statement with context.
+func (l *fieldsLogger) DPanicw(msg string, keysAndValues ...interface{}) {
+	l.log(DPanicLevel, msg, keysAndValues)
+}
+
+// Panicw is logging a panic statement with context.
+func (l *fieldsLogger) Panicw(msg string, keysAndValues ...interface{}) {
+	l.log(PanicLevel, msg, keysAndValues)
+}
+
+// Fatalw is logging a fatal statement with context.
+func (l *fieldsLogger) Fatalw(msg string, keysAndValues ...interface{}) {
+	l.log(FatalLevel, msg, keysAndValues)
+}
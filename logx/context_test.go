@@ -0,0 +1,116 @@
+package logx_test
+
+import (
+	"context"
+	"testing"
+
+	pkgctx "github.com/katallaxie/pkg/ctx"
+	"github.com/katallaxie/pkg/logx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observe(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	t.Cleanup(logx.SetSink(logx.NewLogger(logx.WithLogger(zap.New(core)))))
+
+	return logs
+}
+
+func TestWithFields(t *testing.T) {
+	ctx := logx.WithFields(context.Background(), "request_id", "42")
+	child := logx.WithFields(ctx, "user", "alice")
+	other := logx.WithFields(ctx, "user", "bob")
+
+	assert.Equal(t, []interface{}{"request_id", "42"}, logx.Fields(ctx))
+	assert.Equal(t, []interface{}{"request_id", "42", "user", "alice"}, logx.Fields(child))
+	assert.Equal(t, []interface{}{"request_id", "42", "user", "bob"}, logx.Fields(other))
+	assert.Empty(t, logx.Fields(context.Background()))
+}
+
+func TestFields_RequestID(t *testing.T) {
+	ctx := pkgctx.RequestID.WithValue(logx.WithFields(context.Background(), "a", 1), "42")
+
+	assert.Equal(t, []interface{}{"a", 1, "request_id", "42"}, logx.Fields(ctx))
+}
+
+func TestFields_Trace(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	})
+	ctx := trace.ContextWithSpanContext(logx.WithFields(context.Background(), "a", 1), sc)
+
+	assert.Equal(t, []interface{}{
+		"a", 1,
+		"trace_id", sc.TraceID().String(),
+		"span_id", sc.SpanID().String(),
+	}, logx.Fields(ctx))
+}
+
+func TestFacade_Ctx(t *testing.T) {
+	logs := observe(t)
+	ctx := logx.WithFields(context.Background(), "request_id", "42")
+
+	logx.InfoCtx(ctx, "info", "some", "info")
+	logx.ErrorCtx(ctx, "error")
+	assert.Panics(t, func() { logx.PanicCtx(ctx, "panic") })
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, map[string]interface{}{"request_id": "42", "some": "info"}, entries[0].ContextMap())
+	assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+	assert.Equal(t, map[string]interface{}{"request_id": "42"}, entries[1].ContextMap())
+	assert.Equal(t, zapcore.PanicLevel, entries[2].Level)
+}
+
+func TestFromContext(t *testing.T) {
+	logs := observe(t)
+	ctx := logx.WithFields(context.Background(), "request_id", "42")
+
+	log := logx.FromContext(ctx)
+	log.Infof("hello %s", "world")
+	log.Warnw("warn", "some", "warn")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "hello world", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"request_id": "42"}, entries[0].ContextMap())
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, map[string]interface{}{"request_id": "42", "some": "warn"}, entries[1].ContextMap())
+}
+
+type counter struct{ n int }
+
+func (c *counter) String() string {
+	c.n++
+	return "counter"
+}
+
+func TestWith_Lazy(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := logx.With(logx.NewLogger(logx.WithLogger(zap.New(core))), "a", 1)
+
+	c := &counter{}
+	log.Debugf("value %s", c)
+	assert.Zero(t, c.n)
+
+	log.Infof("value %s", c)
+	assert.Equal(t, 1, c.n)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "value counter", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"a": int64(1)}, entries[0].ContextMap())
+}
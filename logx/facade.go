@@ -1,71 +1,111 @@
 package logx
 
+import (
+	"context"
+	"slices"
+)
+
 // Printf ...
 func Printf(format string, args ...interface{}) {
-	LogSink.Infof(format, args...)
+	root.Infof(format, args...)
 }
 
 // Debugf ...
 func Debugf(format string, args ...interface{}) {
-	LogSink.Debugf(format, args...)
+	root.Debugf(format, args...)
 }
 
 // Infof ...
 func Infof(format string, args ...interface{}) {
-	LogSink.Infof(format, args...)
+	root.Infof(format, args...)
 }
 
 // Errorf ...
 func Errorf(format string, args ...interface{}) {
-	LogSink.Errorf(format, args...)
+	root.Errorf(format, args...)
 }
 
 // Warnf ...
 func Warnf(format string, args ...interface{}) {
-	LogSink.Warnf(format, args...)
+	root.Warnf(format, args...)
 }
 
 // Panicf ...
 func Panicf(format string, args ...interface{}) {
-	LogSink.Panicf(format, args...)
+	root.Panicf(format, args...)
 }
 
 // Fatalf ...
 func Fatalf(format string, args ...interface{}) {
-	LogSink.Fatalf(format, args...)
+	root.Fatalf(format, args...)
 }
 
 // Debugw ...
 func Debugw(msg string, keysAndValues ...interface{}) {
-	LogSink.Debugw(msg, keysAndValues...)
+	root.Debugw(msg, keysAndValues...)
 }
 
 // Infow ...
 func Infow(msg string, keysAndValues ...interface{}) {
-	LogSink.Infow(msg, keysAndValues...)
+	root.Infow(msg, keysAndValues...)
 }
 
 // Warnw ...
 func Warnw(msg string, keysAndValues ...interface{}) {
-	LogSink.Warnw(msg, keysAndValues...)
+	root.Warnw(msg, keysAndValues...)
 }
 
 // Errorw ...
 func Errorw(msg string, keysAndValues ...interface{}) {
-	LogSink.Errorw(msg, keysAndValues...)
+	root.Errorw(msg, keysAndValues...)
 }
 
 // DPanicw ...
 func DPanicw(msg string, keysAndValues ...interface{}) {
-	LogSink.DPanicw(msg, keysAndValues...)
+	root.DPanicw(msg, keysAndValues...)
 }
 
 // Panicw ...
 func Panicw(msg string, keysAndValues ...interface{}) {
-	LogSink.Panicw(msg, keysAndValues...)
+	root.Panicw(msg, keysAndValues...)
 }
 
 // Fatalw ...
 func Fatalw(msg string, keysAndValues ...interface{}) {
-	LogSink.Fatalw(msg, keysAndValues...)
+	root.Fatalw(msg, keysAndValues...)
+}
+
+// DebugCtx is logging a debug statement with the fields of the context.
+func DebugCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	root.Debugw(msg, slices.Concat(Fields(ctx), keysAndValues)...)
+}
+
+// InfoCtx is logging an info statement with the fields of the context.
+func InfoCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	root.Infow(msg, slices.Concat(Fields(ctx), keysAndValues)...)
+}
+
+// WarnCtx is logging a warning statement with the fields of the context.
+func WarnCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	root.Warnw(msg, slices.Concat(Fields(ctx), keysAndValues)...)
+}
+
+// ErrorCtx is logging an error statement with the fields of the context.
+func ErrorCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	root.Errorw(msg, slices.Concat(Fields(ctx), keysAndValues)...)
+}
+
+// DPanicCtx is logging a debug panic statement with the fields of the context.
+func DPanicCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	root.DPanicw(msg, slices.Concat(Fields(ctx), keysAndValues)...)
+}
+
+// PanicCtx is logging a panic statement with the fields of the context.
+func PanicCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	root.Panicw(msg, slices.Concat(Fields(ctx), keysAndValues)...)
+}
+
+// FatalCtx is logging a fatal statement with the fields of the context.
+func FatalCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	root.Fatalw(msg, slices.Concat(Fields(ctx), keysAndValues)...)
 }
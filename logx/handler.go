@@ -0,0 +1,96 @@
+package logx
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+var _ slog.Handler = (*handler)(nil)
+
+type handler struct {
+	logger Logger
+	attrs  []interface{}
+	prefix string
+}
+
+// NewHandler returns a slog handler forwarding the records to the logger,
+// so libraries using log/slog write to the same sink. Groups are flattened
+// into dotted keys, and the fields of the context are added to the records.
+func NewHandler(l Logger) slog.Handler {
+	return &handler{logger: l}
+}
+
+// Enabled reports whether the logger writes records of the level.
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return enabled(h.logger, fromSlog(level))
+}
+
+// Handle forwards the record to the logger.
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	keysAndValues := slices.Concat(Fields(ctx), h.attrs)
+
+	r.Attrs(func(a slog.Attr) bool {
+		keysAndValues = appendAttr(keysAndValues, h.prefix, a)
+		return true
+	})
+
+	switch fromSlog(r.Level) {
+	case DebugLevel:
+		h.logger.Debugw(r.Message, keysAndValues...)
+	case InfoLevel:
+		h.logger.Infow(r.Message, keysAndValues...)
+	case WarnLevel:
+		h.logger.Warnw(r.Message, keysAndValues...)
+	default:
+		h.logger.Errorw(r.Message, keysAndValues...)
+	}
+
+	return nil
+}
+
+// WithAttrs returns a handler adding the attributes to the records.
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = slices.Clip(h.attrs)
+
+	for _, a := range attrs {
+		c.attrs = appendAttr(c.attrs, h.prefix, a)
+	}
+
+	return &c
+}
+
+// WithGroup returns a handler prefixing the keys of the attributes with the group.
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	c := *h
+	c.prefix = h.prefix + name + "."
+
+	return &c
+}
+
+func appendAttr(keysAndValues []interface{}, prefix string, a slog.Attr) []interface{} {
+	a.Value = a.Value.Resolve()
+
+	if a.Equal(slog.Attr{}) {
+		return keysAndValues
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+
+		for _, ga := range a.Value.Group() {
+			keysAndValues = appendAttr(keysAndValues, prefix, ga)
+		}
+
+		return keysAndValues
+	}
+
+	return append(keysAndValues, prefix+a.Key, a.Value.Any())
+}
@@ -0,0 +1,44 @@
+package logx_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/katallaxie/pkg/logx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := slog.New(logx.NewHandler(logx.NewLogger(logx.WithLogger(zap.New(core)))))
+
+	ctx := logx.WithFields(context.Background(), "request_id", "42")
+
+	log.DebugContext(ctx, "debug")
+	log.With("service", "db").WithGroup("query").InfoContext(ctx, "info", "table", "users", slog.Group("rows", "count", 3))
+	log.Warn("warn")
+	log.Log(ctx, slog.LevelError+4, "error")
+
+	assert.False(t, log.Enabled(ctx, slog.LevelDebug))
+	assert.True(t, log.Enabled(ctx, slog.LevelInfo))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "info", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"request_id":       "42",
+		"service":          "db",
+		"query.table":      "users",
+		"query.rows.count": int64(3),
+	}, entries[0].ContextMap())
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, zapcore.ErrorLevel, entries[2].Level)
+}
@@ -0,0 +1,226 @@
+package logx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidLevel is returned when a level cannot be parsed.
+var ErrInvalidLevel = errors.New("logx: invalid level")
+
+// EnvLevel is the environment variable with the initial levels,
+// e.g. "warn" or "info,db=debug,http=warn".
+const EnvLevel = "LOG_LEVEL"
+
+// Level is the level of a log statement.
+type Level int8
+
+const (
+	// DebugLevel is the level of debug statements.
+	DebugLevel Level = iota - 1
+	// InfoLevel is the level of info statements.
+	InfoLevel
+	// WarnLevel is the level of warning statements.
+	WarnLevel
+	// ErrorLevel is the level of error statements.
+	ErrorLevel
+	// DPanicLevel is the level of debug panic statements.
+	DPanicLevel
+	// PanicLevel is the level of panic statements, the logger panics after logging.
+	PanicLevel
+	// FatalLevel is the level of fatal statements, the logger exits after logging.
+	FatalLevel
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case DPanicLevel:
+		return "dpanic"
+	case PanicLevel:
+		return "panic"
+	case FatalLevel:
+		return "fatal"
+	default:
+		return fmt.Sprintf("Level(%d)", l)
+	}
+}
+
+// ParseLevel parses the name of a level, e.g. "debug" or "warn".
+func ParseLevel(s string) (Level, error) {
+	var l Level
+	err := l.UnmarshalText([]byte(s))
+
+	return l, err
+}
+
+// MarshalText returns the name of the level.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText parses the name of a level.
+func (l *Level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(strings.TrimSpace(string(text))) {
+	case "debug", "trace":
+		*l = DebugLevel
+	case "info", "notice":
+		*l = InfoLevel
+	case "warn", "warning":
+		*l = WarnLevel
+	case "error":
+		*l = ErrorLevel
+	case "dpanic":
+		*l = DPanicLevel
+	case "panic":
+		*l = PanicLevel
+	case "fatal":
+		*l = FatalLevel
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidLevel, text)
+	}
+
+	return nil
+}
+
+// ConfigureLevels is setting the levels of the spec, a comma separated list
+// of the global level and levels of named loggers, e.g. "info,db=debug".
+// No level is set if the spec is invalid, the global level is kept if the spec has none.
+func ConfigureLevels(spec string) error {
+	var global *Level
+
+	named := map[string]Level{}
+
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			value, name = name, ""
+		}
+
+		level, err := ParseLevel(value)
+		if err != nil {
+			return err
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			global = &level
+			continue
+		}
+
+		named[name] = level
+	}
+
+	if global != nil {
+		SetLevel(*global)
+	}
+
+	for name, level := range named {
+		Named(name).SetLevel(level)
+	}
+
+	return nil
+}
+
+// LevelState is the state of the levels served by the level handler.
+type LevelState struct {
+	// Level is the global level.
+	Level Level `json:"level"`
+	// Loggers are the levels of the named loggers.
+	Loggers map[string]Level `json:"loggers,omitempty"`
+}
+
+const (
+	// maxLevelRequestSize is the maximum size of the body of a level request.
+	maxLevelRequestSize = 64 << 10
+	// maxNameLength is the maximum length of the name of a named logger in a level request.
+	maxNameLength = 128
+)
+
+type levelRequest struct {
+	Level   *Level            `json:"level"`
+	Loggers map[string]*Level `json:"loggers"`
+}
+
+type levelHandler struct{}
+
+// LevelHandler returns a handler to change the levels at runtime,
+// e.g. mounted on the debug listener of the server. GET returns the levels,
+// PUT sets the levels of the body, e.g. {"level":"info","loggers":{"db":"debug"}},
+// a null level resets an existing named logger to the global level.
+func LevelHandler() http.Handler {
+	return levelHandler{}
+}
+
+// ServeHTTP is serving the levels.
+func (levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req levelRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLevelRequestSize)).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid levels: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		for name := range req.Loggers {
+			if !validName(name) {
+				http.Error(w, fmt.Sprintf("invalid logger name: %q", name), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if req.Level != nil {
+			SetLevel(*req.Level)
+		}
+
+		for name, level := range req.Loggers {
+			if level != nil {
+				Named(name).SetLevel(*level)
+				continue
+			}
+
+			if n, ok := lookup(name); ok {
+				n.ResetLevel()
+			}
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(LevelState{Level: GetLevel(), Loggers: Levels()})
+}
+
+// validName reports whether the name of a named logger is not empty,
+// not longer than maxNameLength and without spaces or control characters.
+func validName(name string) bool {
+	if name == "" || len(name) > maxNameLength {
+		return false
+	}
+
+	return !strings.ContainsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || !unicode.IsPrint(r)
+	})
+}
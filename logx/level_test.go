@@ -0,0 +1,153 @@
+package logx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/katallaxie/pkg/logx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func setLevel(t *testing.T, level logx.Level) {
+	t.Helper()
+
+	logx.SetLevel(level)
+	t.Cleanup(logx.ResetLevel)
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want logx.Level
+	}{
+		{"debug", logx.DebugLevel},
+		{"INFO", logx.InfoLevel},
+		{"warning", logx.WarnLevel},
+		{"error", logx.ErrorLevel},
+		{"fatal", logx.FatalLevel},
+	}
+
+	for _, tt := range tests {
+		l, err := logx.ParseLevel(tt.in)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, l)
+	}
+
+	_, err := logx.ParseLevel("verbose")
+	require.ErrorIs(t, err, logx.ErrInvalidLevel)
+}
+
+func TestNamed(t *testing.T) {
+	logs := observe(t)
+	setLevel(t, logx.InfoLevel)
+
+	db := logx.Named("named-db")
+	assert.Same(t, db, logx.Named("named-db"))
+	assert.Equal(t, logx.InfoLevel, db.Level())
+
+	logx.Debugw("global")
+	db.Debugw("db")
+
+	db.SetLevel(logx.DebugLevel)
+	logx.Debugw("global")
+	db.Debugw("db", "query", "select")
+
+	db.ResetLevel()
+	db.Debugw("db")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, map[string]interface{}{"logger": "named-db", "query": "select"}, entries[0].ContextMap())
+
+	setLevel(t, logx.ErrorLevel)
+	assert.Equal(t, logx.ErrorLevel, db.Level())
+	assert.False(t, db.Enabled(logx.WarnLevel))
+	assert.Panics(t, func() { db.Panicf("panic") })
+}
+
+func TestNewLogSink(t *testing.T) {
+	setLevel(t, logx.InfoLevel)
+
+	sink, err := logx.NewLogSink()
+	require.NoError(t, err)
+	assert.False(t, sink.Core().Enabled(zapcore.DebugLevel))
+	assert.True(t, sink.Core().Enabled(zapcore.InfoLevel))
+
+	db := logx.Named("sink-db")
+	db.SetLevel(logx.DebugLevel)
+	assert.True(t, sink.Core().Enabled(zapcore.DebugLevel))
+
+	db.ResetLevel()
+	assert.False(t, sink.Core().Enabled(zapcore.DebugLevel))
+
+	setLevel(t, logx.WarnLevel)
+	assert.False(t, sink.Core().Enabled(zapcore.InfoLevel))
+}
+
+func TestConfigureLevels(t *testing.T) {
+	setLevel(t, logx.InfoLevel)
+	t.Cleanup(logx.Named("configure-db").ResetLevel)
+
+	require.NoError(t, logx.ConfigureLevels("warn, configure-db=debug"))
+	assert.Equal(t, logx.WarnLevel, logx.GetLevel())
+	assert.Equal(t, logx.DebugLevel, logx.Named("configure-db").Level())
+
+	require.ErrorIs(t, logx.ConfigureLevels("debug,configure-http=loud"), logx.ErrInvalidLevel)
+	assert.Equal(t, logx.WarnLevel, logx.GetLevel())
+}
+
+func TestLevelHandler(t *testing.T) {
+	setLevel(t, logx.InfoLevel)
+
+	h := logx.LevelHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"warn","loggers":{"handler-db":"debug"}}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, logx.WarnLevel, logx.GetLevel())
+	assert.Equal(t, logx.DebugLevel, logx.Named("handler-db").Level())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"level":"warn"`)
+	assert.Contains(t, rec.Body.String(), `"handler-db":"debug"`)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"loggers":{"handler-db":null}}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, logx.WarnLevel, logx.Named("handler-db").Level())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"loud"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	n := len(logx.Levels())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"loggers":{"handler-unknown":null}}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, logx.Levels(), n)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"error","loggers":{"handler db":"debug"}}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, logx.WarnLevel, logx.GetLevel())
+	assert.Len(t, logx.Levels(), n)
+
+	rec = httptest.NewRecorder()
+	body := `{"level":"warn",` + strings.Repeat(" ", 64<<10) + `"loggers":{"handler-big":"debug"}}`
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Len(t, logx.Levels(), n)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -1,13 +1,29 @@
 package logx
 
 import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
 
-// LogSink is the logger sink.
-var LogSink Logger
+// LogSink is the logger sink, it is forwarding to the logger set with SetSink.
+// It should not be assigned, as the assignment races with the statements logged concurrently.
+var LogSink Logger = defaultSink
+
+var (
+	defaultSink = new(sink)
+
+	// packageSink is the logger of the logger sink built by the package.
+	packageSink Logger
+)
 
 func init() {
 	l, err := NewLogSink()
@@ -15,14 +31,21 @@ func init() {
 		panic(err)
 	}
 
-	log := NewLogger(WithLogger(l))
+	packageSink = NewLogger(WithLogger(l))
+	SetSink(packageSink)
 
-	LogSink = log
+	if err := ConfigureLevels(os.Getenv(EnvLevel)); err != nil {
+		LogSink.Warnw("invalid log levels", "env", EnvLevel, "error", err)
+	}
 }
 
-// NewLogSink returns a new logger sink.
+// NewLogSink returns a new logger sink, its level is the lowest of the global
+// and the named levels, and is following SetLevel and the levels of the named loggers.
 func NewLogSink() (*zap.Logger, error) {
-	return zap.NewProduction()
+	cfg := zap.NewProductionConfig()
+	cfg.Level = sinkLevel
+
+	return cfg.Build()
 }
 
 // Logger represents a standard logging interface.
@@ -70,8 +93,11 @@ type LogFunc func(string, ...interface{})
 func (f LogFunc) Printf(msg string, args ...interface{}) { f(msg, args...) }
 
 type logger struct {
-	opts *Opts
-	sync.RWMutex
+	opts    *Opts
+	sampler *sampler
+	limiter *limiter
+	deduper *deduper
+	sync.Mutex
 }
 
 // Opt is a logger option.
@@ -79,7 +105,14 @@ type Opt func(*Opts)
 
 // Opts are the options for the logger.
 type Opts struct {
-	Logger *zap.Logger
+	// Backend is writing the statements.
+	Backend Backend
+	// Sampling is sampling the statements with the same level and message.
+	Sampling *Sampling
+	// RateLimit is limiting the statements with the same level and message.
+	RateLimit *RateLimit
+	// Dedup is the window in which repeated identical statements are collapsed.
+	Dedup time.Duration
 }
 
 // Configure is configuring the logger.
@@ -89,14 +122,52 @@ func (o *Opts) Configure(opts ...Opt) {
 	}
 }
 
-// WithLogger is setting the logger.
+// WithLogger is setting a zap logger as backend.
 func WithLogger(l *zap.Logger) Opt {
 	return func(o *Opts) {
-		o.Logger = l
+		o.Backend = NewZapBackend(l)
+	}
+}
+
+// WithSlog is setting a slog logger as backend.
+func WithSlog(l *slog.Logger) Opt {
+	return func(o *Opts) {
+		o.Backend = NewSlogBackend(l)
+	}
+}
+
+// WithBackend is setting the backend.
+func WithBackend(b Backend) Opt {
+	return func(o *Opts) {
+		o.Backend = b
+	}
+}
+
+// WithSampling is logging the first statements with the same level and message
+// in each interval, and then every thereafter statement.
+func WithSampling(interval time.Duration, first, thereafter int) Opt {
+	return func(o *Opts) {
+		o.Sampling = &Sampling{Interval: interval, First: first, Thereafter: thereafter}
+	}
+}
+
+// WithRateLimit is logging at most limit statements with the same level and message in each interval.
+func WithRateLimit(limit int, interval time.Duration) Opt {
+	return func(o *Opts) {
+		o.RateLimit = &RateLimit{Interval: interval, Limit: limit}
+	}
+}
+
+// WithDedup is collapsing repeated identical statements in the window
+// into one statement with the number of repeats as "repeated" field.
+func WithDedup(window time.Duration) Opt {
+	return func(o *Opts) {
+		o.Dedup = window
 	}
 }
 
 // NewLogger is creating a new logger.
+// Panic and fatal statements are never sampled, limited or collapsed.
 func NewLogger(o ...Opt) Logger {
 	options := new(Opts)
 	options.Configure(o...)
@@ -104,133 +175,287 @@ func NewLogger(o ...Opt) Logger {
 	l := new(logger)
 	l.opts = options
 
+	if options.Sampling != nil {
+		l.sampler = &sampler{opts: *options.Sampling, windows: windows{}}
+	}
+
+	if options.RateLimit != nil {
+		l.limiter = &limiter{opts: *options.RateLimit, windows: windows{}}
+	}
+
+	if options.Dedup > 0 {
+		l.deduper = &deduper{window: options.Dedup}
+	}
+
 	return l
 }
 
+// Enabled reports whether statements of the level are written.
+func (l *logger) Enabled(level Level) bool {
+	return l.opts.Backend != nil && l.opts.Backend.Enabled(level)
+}
+
 // Errorf is logging an error.
 func (l *logger) Errorf(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Errorf(format, v...)
-	}, format, v...)
+	l.logf(ErrorLevel, format, v...)
 }
 
 // Debugf is logging a debug statement.
 func (l *logger) Debugf(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Debugf(format, v...)
-	}, format, v...)
+	l.logf(DebugLevel, format, v...)
 }
 
 // Fatalf is logging a fatal error.
 func (l *logger) Fatalf(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Fatalf(format, v...)
-	}, format, v...)
+	l.logf(FatalLevel, format, v...)
 }
 
 // Noticef is logging a notice statement.
 func (l *logger) Noticef(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Infof(format, v...)
-	}, format, v...)
+	l.logf(InfoLevel, format, v...)
 }
 
 // Warnf is logging a warning statement.
 func (l *logger) Warnf(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Warnf(format, v...)
-	}, format, v...)
+	l.logf(WarnLevel, format, v...)
 }
 
 // Tracef is logging a trace statement.
 func (l *logger) Tracef(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Debugf(format, v...)
-	}, format, v...)
+	l.logf(DebugLevel, format, v...)
 }
 
 // Infof is logging an info statement.
 func (l *logger) Infof(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Infof(format, v...)
-	}, format, v...)
+	l.logf(InfoLevel, format, v...)
 }
 
 // Panicf is logging a panic statement.
 func (l *logger) Panicf(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Panicf(format, v...)
-	}, format, v...)
+	l.logf(PanicLevel, format, v...)
 }
 
 // Printf is logging a printf statement.
 func (l *logger) Printf(format string, v ...interface{}) {
-	l.logFunc(func(log *zap.Logger, format string, v ...interface{}) {
-		log.Sugar().Infof(format, v...)
-	}, format, v...)
+	l.logf(InfoLevel, format, v...)
 }
 
 // Debugw is logging a debug statement with context.
 func (l *logger) Debugw(msg string, keysAndValues ...interface{}) {
-	l.logFunc(func(log *zap.Logger, msg string, keysAndValues ...interface{}) {
-		log.Sugar().Debugw(msg, keysAndValues...)
-	}, msg, keysAndValues...)
+	l.log(DebugLevel, msg, keysAndValues...)
 }
 
 // Infow is logging an info statement with context.
 func (l *logger) Infow(msg string, keysAndValues ...interface{}) {
-	l.logFunc(func(log *zap.Logger, msg string, keysAndValues ...interface{}) {
-		log.Sugar().Infow(msg, keysAndValues...)
-	}, msg, keysAndValues...)
+	l.log(InfoLevel, msg, keysAndValues...)
 }
 
 // Warnw is logging a warning statement with context.
 func (l *logger) Warnw(msg string, keysAndValues ...interface{}) {
-	l.logFunc(func(log *zap.Logger, msg string, keysAndValues ...interface{}) {
-		log.Sugar().Warnw(msg, keysAndValues...)
-	}, msg, keysAndValues...)
+	l.log(WarnLevel, msg, keysAndValues...)
 }
 
 // Errorw is logging an error statement with context.
 func (l *logger) Errorw(msg string, keysAndValues ...interface{}) {
-	l.logFunc(func(log *zap.Logger, msg string, keysAndValues ...interface{}) {
-		log.Sugar().Errorw(msg, keysAndValues...)
-	}, msg, keysAndValues...)
+	l.log(ErrorLevel, msg, keysAndValues...)
 }
 
 // DPanicw is logging a debug panic statement with context.
 func (l *logger) DPanicw(msg string, keysAndValues ...interface{}) {
-	l.logFunc(func(log *zap.Logger, msg string, keysAndValues ...interface{}) {
-		log.Sugar().DPanicw(msg, keysAndValues...)
-	}, msg, keysAndValues...)
+	l.log(DPanicLevel, msg, keysAndValues...)
 }
 
 // Panicw is logging a panic statement with context.
 func (l *logger) Panicw(msg string, keysAndValues ...interface{}) {
-	l.logFunc(func(log *zap.Logger, msg string, keysAndValues ...interface{}) {
-		log.Sugar().Panicw(msg, keysAndValues...)
-	}, msg, keysAndValues...)
+	l.log(PanicLevel, msg, keysAndValues...)
 }
 
 // Fatalw is logging a fatal statement with context.
 func (l *logger) Fatalw(msg string, keysAndValues ...interface{}) {
-	l.logFunc(func(log *zap.Logger, msg string, keysAndValues ...interface{}) {
-		log.Sugar().Fatalw(msg, keysAndValues...)
-	}, msg, keysAndValues...)
+	l.log(FatalLevel, msg, keysAndValues...)
+}
+
+func (l *logger) logf(level Level, format string, v ...interface{}) {
+	l.write(level, format, func() string { return fmt.Sprintf(format, v...) })
 }
 
-func (l *logger) logFunc(f func(log *zap.Logger, format string, v ...interface{}), format string, args ...interface{}) {
+func (l *logger) log(level Level, msg string, keysAndValues ...interface{}) {
+	l.write(level, msg, func() string { return msg }, keysAndValues...)
+}
+
+// write is writing the statement to the backend, the statement is sampled
+// and limited by the level and the id, which is the message or the format.
+// The lock is only held for the state of the sampler, the limiter and the deduper.
+func (l *logger) write(level Level, id string, msg func() string, keysAndValues ...interface{}) {
+	b := l.opts.Backend
+	if b == nil {
+		return
+	}
+
+	if level < PanicLevel {
+		if !b.Enabled(level) || !l.allow(key{level: level, msg: id}, &keysAndValues) {
+			return
+		}
+	}
+
+	r := record{level: level, msg: msg(), keysAndValues: keysAndValues}
+
+	if l.deduper != nil && level < PanicLevel {
+		ok, pending := l.dedup(r)
+		if pending != nil {
+			b.Log(pending.level, pending.msg, pending.keysAndValues...)
+		}
+
+		if !ok {
+			return
+		}
+	}
+
+	b.Log(r.level, r.msg, r.keysAndValues...)
+
+	// The zap backend panics and exits itself, the other backends are only writing
+	// the statement, e.g. slog, so the logger panics and exits for them.
+	switch level {
+	case PanicLevel:
+		panic(r.msg)
+	case FatalLevel:
+		os.Exit(1)
+	}
+}
+
+// allow reports whether the statement passes the sampling and the rate limit,
+// the number of statements dropped by the rate limit is added to the fields.
+func (l *logger) allow(k key, keysAndValues *[]interface{}) bool {
+	if l.sampler == nil && l.limiter == nil {
+		return true
+	}
+
+	now := time.Now()
+
 	l.Lock()
 	defer l.Unlock()
 
-	if l.opts.Logger == nil {
+	if l.sampler != nil && !l.sampler.allow(k, now) {
+		return false
+	}
+
+	if l.limiter != nil {
+		ok, dropped := l.limiter.allow(k, now)
+		if !ok {
+			return false
+		}
+
+		if dropped > 0 {
+			*keysAndValues = append(slices.Clip(*keysAndValues), "dropped", dropped)
+		}
+	}
+
+	return true
+}
+
+// dedup returns whether the statement is logged, and the pending repeats to log before it.
+func (l *logger) dedup(r record) (bool, *record) {
+	id := fmt.Sprint(r.level, r.msg, r.keysAndValues)
+	now := time.Now()
+
+	l.Lock()
+	defer l.Unlock()
+
+	ok, pending := l.deduper.add(r, id, now)
+	if ok {
+		l.schedule()
+	}
+
+	return ok, pending
+}
+
+// schedule is logging the pending repeats when the window of the deduper ends.
+func (l *logger) schedule() {
+	if l.deduper.timer != nil {
+		l.deduper.timer.Stop()
+	}
+
+	l.deduper.timer = time.AfterFunc(l.deduper.window, func() {
+		l.Lock()
+		pending := l.deduper.flush()
+		l.Unlock()
+
+		if pending != nil {
+			l.opts.Backend.Log(pending.level, pending.msg, pending.keysAndValues...)
+		}
+	})
+}
+
+// writer is implemented by the loggers of the package, the statements are passed
+// with the format as id to be sampled by it, and the message is formatted lazily.
+type writer interface {
+	write(level Level, id string, msg func() string, keysAndValues ...interface{})
+}
+
+// writeTo is writing the statement to the logger,
+// the message is only formatted if the level is enabled.
+func writeTo(l Logger, level Level, id string, msg func() string, keysAndValues ...interface{}) {
+	if w, ok := l.(writer); ok {
+		w.write(level, id, msg, keysAndValues...)
 		return
 	}
 
-	f(l.opts.Logger, format, args...)
+	if level < PanicLevel && !enabled(l, level) {
+		return
+	}
+
+	switch level {
+	case DebugLevel:
+		l.Debugw(msg(), keysAndValues...)
+	case InfoLevel:
+		l.Infow(msg(), keysAndValues...)
+	case WarnLevel:
+		l.Warnw(msg(), keysAndValues...)
+	case ErrorLevel:
+		l.Errorw(msg(), keysAndValues...)
+	case DPanicLevel:
+		l.DPanicw(msg(), keysAndValues...)
+	case PanicLevel:
+		l.Panicw(msg(), keysAndValues...)
+	default:
+		l.Fatalw(msg(), keysAndValues...)
+	}
+}
+
+// enabled reports whether the logger writes statements of the level,
+// loggers without a level are assumed to write all statements.
+func enabled(l Logger, level Level) bool {
+	if e, ok := l.(interface{ Enabled(level Level) bool }); ok {
+		return e.Enabled(level)
+	}
+
+	return true
+}
+
+var _ io.Writer = stdWriter{}
+
+type stdWriter struct {
+	logger Logger
 }
 
-// RedirectStdLog is redirecting the standard logger to the logger.
+func (w stdWriter) Write(p []byte) (int, error) {
+	w.logger.Debugw(strings.TrimSuffix(string(p), "\n"))
+
+	return len(p), nil
+}
+
+// RedirectStdLog is redirecting the standard logger to the logger,
+// the returned function restores the standard logger.
 func RedirectStdLog(l Logger) (func(), error) {
-	return zap.RedirectStdLogAt(l.(*logger).opts.Logger, zap.DebugLevel)
+	flags, prefix, output := log.Flags(), log.Prefix(), log.Writer()
+
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(stdWriter{logger: l})
+
+	return func() {
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+		log.SetOutput(output)
+	}, nil
 }
@@ -1,11 +1,17 @@
 package logx_test
 
 import (
+	"bytes"
+	"log"
+	"log/slog"
+	"sync"
 	"testing"
 
 	"github.com/katallaxie/pkg/logx"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestFacade(t *testing.T) {
@@ -23,3 +29,71 @@ func TestFacade(t *testing.T) {
 	assert.Panics(t, func() { logx.Panicw("test", "some", "panic") })
 	logx.Errorw("test", "some", "error")
 }
+
+func TestSetSink_Level(t *testing.T) {
+	logs := observe(t)
+
+	assert.Equal(t, logx.DebugLevel, logx.GetLevel())
+
+	logx.Debugw("debug", "some", "debug")
+	logx.Debugf("debug %d", 1)
+	logx.Named("sink-level").Debugw("named")
+	assert.Equal(t, 3, logs.FilterLevelExact(zapcore.DebugLevel).Len())
+
+	setLevel(t, logx.InfoLevel)
+	logx.Debugw("hidden")
+	assert.Equal(t, 3, logs.Len())
+}
+
+func TestSetSink(t *testing.T) {
+	logs := observe(t)
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Go(func() {
+			for range 100 {
+				logx.Named("sink-db").Warnw("concurrent")
+			}
+		})
+	}
+
+	for range 10 {
+		restore := logx.SetSink(logx.NewLogger())
+		restore()
+	}
+
+	wg.Wait()
+
+	logx.Warnw("restored")
+	assert.Equal(t, 1, logs.FilterMessage("restored").Len())
+}
+
+func TestNewLogger_Slog(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := logx.NewLogger(logx.WithSlog(slog.New(slog.NewTextHandler(&buf, nil))))
+
+	log.Debugw("debug")
+	log.Infof("hello %s", "world")
+	log.Errorw("error", "some", "error")
+	assert.Panics(t, func() { log.Panicw("panic") })
+
+	out := buf.String()
+	assert.NotContains(t, out, "msg=debug")
+	assert.Contains(t, out, `level=INFO msg="hello world"`)
+	assert.Contains(t, out, "level=ERROR msg=error some=error")
+	assert.Contains(t, out, "level=ERROR msg=panic")
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	var buf bytes.Buffer
+
+	restore, err := logx.RedirectStdLog(logx.NewLogger(logx.WithSlog(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))))
+	require.NoError(t, err)
+
+	log.Print("redirected")
+	restore()
+
+	assert.Contains(t, buf.String(), "level=DEBUG msg=redirected")
+}
@@ -0,0 +1,200 @@
+// Package logxtest provides an observer to test the statements logged with logx.
+package logxtest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/katallaxie/pkg/logx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// badKey is the key of a value without a key.
+const badKey = "!BADKEY"
+
+// Entry is a logged statement.
+type Entry struct {
+	// Level is the level of the statement.
+	Level logx.Level
+	// Message is the message of the statement.
+	Message string
+	// Fields are the fields of the statement.
+	Fields map[string]interface{}
+}
+
+// String returns the entry as level, message and fields.
+func (e Entry) String() string {
+	return fmt.Sprintf("%s %q %v", e.Level, e.Message, e.Fields)
+}
+
+// Has reports whether the entry has the fields.
+func (e Entry) Has(keysAndValues ...interface{}) bool {
+	for k, v := range fields(keysAndValues) {
+		actual, ok := e.Fields[k]
+		if !ok || !assert.ObjectsAreEqual(v, actual) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var _ logx.Backend = (*Observer)(nil)
+
+// Observer is a backend recording the logged statements.
+type Observer struct {
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+// NewObserver returns a new observer.
+func NewObserver() *Observer {
+	return new(Observer)
+}
+
+// New returns a new logger writing to a new observer.
+func New(opts ...logx.Opt) (logx.Logger, *Observer) {
+	o := NewObserver()
+
+	return logx.NewLogger(append([]logx.Opt{logx.WithBackend(o)}, opts...)...), o
+}
+
+// Enabled reports whether statements of the level are recorded, it records all levels.
+func (o *Observer) Enabled(logx.Level) bool {
+	return true
+}
+
+// Log is recording the statement.
+func (o *Observer) Log(level logx.Level, msg string, keysAndValues ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.entries = append(o.entries, Entry{Level: level, Message: msg, Fields: fields(keysAndValues)})
+}
+
+// Entries returns the recorded entries.
+func (o *Observer) Entries() []Entry {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return slices.Clone(o.entries)
+}
+
+// Len returns the number of recorded entries.
+func (o *Observer) Len() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return len(o.entries)
+}
+
+// Reset is removing the recorded entries.
+func (o *Observer) Reset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.entries = nil
+}
+
+// Filter returns the entries with the level and message which have the fields.
+func (o *Observer) Filter(level logx.Level, msg string, keysAndValues ...interface{}) []Entry {
+	var entries []Entry
+
+	for _, e := range o.Entries() {
+		if e.Level == level && e.Message == msg && e.Has(keysAndValues...) {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries
+}
+
+// AssertLogged asserts that a statement with the level and message was logged with the fields.
+func (o *Observer) AssertLogged(t assert.TestingT, level logx.Level, msg string, keysAndValues ...interface{}) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if len(o.Filter(level, msg, keysAndValues...)) > 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("%s %q %v was not logged", level, msg, fields(keysAndValues)), "logged:\n%s", o)
+}
+
+// AssertNotLogged asserts that no statement with the level and message was logged with the fields.
+func (o *Observer) AssertNotLogged(t assert.TestingT, level logx.Level, msg string, keysAndValues ...interface{}) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if len(o.Filter(level, msg, keysAndValues...)) == 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("%s %q %v was logged", level, msg, fields(keysAndValues)), "logged:\n%s", o)
+}
+
+// String returns the recorded entries, one per line.
+func (o *Observer) String() string {
+	var b strings.Builder
+
+	for _, e := range o.Entries() {
+		b.WriteString(e.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// swapped reports whether the logger sink is swapped by a test.
+var swapped atomic.Bool
+
+// Swap is setting the logger sink to a logger writing to a new observer with logx.SetSink,
+// the logger sink is restored when the test and its subtests complete.
+// Only one test can swap the logger sink at a time, the test fails if it is already swapped,
+// e.g. by a parallel test or a parent test. The observer is recording all levels,
+// so the global level is debug until it is set, and the named levels still apply.
+func Swap(t testing.TB, opts ...logx.Opt) *Observer {
+	t.Helper()
+
+	if !swapped.CompareAndSwap(false, true) {
+		t.Fatal("logxtest: the logger sink is already swapped")
+		return nil
+	}
+
+	log, o := New(opts...)
+	restore := logx.SetSink(log)
+
+	t.Cleanup(func() {
+		restore()
+		swapped.Store(false)
+	})
+
+	return o
+}
+
+func fields(keysAndValues []interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(keysAndValues)/2)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			m[badKey] = keysAndValues[i]
+			break
+		}
+
+		k, ok := keysAndValues[i].(string)
+		if !ok {
+			k = fmt.Sprint(keysAndValues[i])
+		}
+
+		m[k] = keysAndValues[i+1]
+	}
+
+	return m
+}
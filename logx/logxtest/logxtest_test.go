@@ -0,0 +1,95 @@
+package logxtest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/katallaxie/pkg/logx"
+	"github.com/katallaxie/pkg/logx/logxtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestObserver(t *testing.T) {
+	log, o := logxtest.New()
+
+	log.Infow("hello", "user", "alice", "attempt", 1)
+	log.Errorf("failed %d times", 3)
+	log.Warnw("odd", "key")
+
+	entries := o.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, logxtest.Entry{
+		Level:   logx.InfoLevel,
+		Message: "hello",
+		Fields:  map[string]interface{}{"user": "alice", "attempt": 1},
+	}, entries[0])
+	assert.Equal(t, "failed 3 times", entries[1].Message)
+	assert.Equal(t, map[string]interface{}{"!BADKEY": "key"}, entries[2].Fields)
+
+	o.AssertLogged(t, logx.InfoLevel, "hello")
+	o.AssertLogged(t, logx.InfoLevel, "hello", "user", "alice")
+	o.AssertNotLogged(t, logx.InfoLevel, "hello", "user", "bob")
+	o.AssertNotLogged(t, logx.DebugLevel, "hello")
+
+	ft := new(fakeT)
+	assert.False(t, o.AssertLogged(ft, logx.ErrorLevel, "hello"))
+	assert.False(t, o.AssertNotLogged(ft, logx.InfoLevel, "hello"))
+	require.Len(t, ft.errors, 2)
+	assert.Contains(t, ft.errors[0], `error "hello" map[] was not logged`)
+
+	o.Reset()
+	assert.Equal(t, 0, o.Len())
+}
+
+type fakeTB struct {
+	testing.TB
+	fatal string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Fatal(args ...interface{}) {
+	t.fatal = fmt.Sprint(args...)
+}
+
+func TestSwap(t *testing.T) {
+	t.Run("swap", func(t *testing.T) {
+		o := logxtest.Swap(t)
+
+		logx.SetLevel(logx.InfoLevel)
+		t.Cleanup(logx.ResetLevel)
+
+		logx.InfoCtx(logx.WithFields(context.Background(), "request_id", "42"), "handled")
+		logx.Named("swap-db").Warnw("slow")
+		logx.Debugw("hidden")
+
+		o.AssertLogged(t, logx.InfoLevel, "handled", "request_id", "42")
+		o.AssertLogged(t, logx.WarnLevel, "slow", "logger", "swap-db")
+		o.AssertNotLogged(t, logx.DebugLevel, "hidden")
+
+		ft := &fakeTB{TB: t}
+		assert.Nil(t, logxtest.Swap(ft))
+		assert.Equal(t, "logxtest: the logger sink is already swapped", ft.fatal)
+
+		restore := logx.SetSink(logx.NewLogger())
+		logx.Infow("detached")
+		restore()
+
+		o.AssertNotLogged(t, logx.InfoLevel, "detached")
+	})
+
+	o := logxtest.Swap(t)
+	logx.Infow("again")
+	o.AssertLogged(t, logx.InfoLevel, "again")
+}
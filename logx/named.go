@@ -0,0 +1,278 @@
+package logx
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// unset is the level of a named logger inheriting the global level.
+const unset = math.MinInt32
+
+var (
+	// root is the logger of the package functions with the global level.
+	root = newNamed("")
+
+	namedMu sync.RWMutex
+	loggers = map[string]*named{}
+
+	// sinkLevel is the level of the logger sink, the lowest of the global and the named levels.
+	sinkLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+)
+
+var _ Logger = (*named)(nil)
+
+type named struct {
+	name  string
+	level atomic.Int32
+}
+
+func newNamed(name string) *named {
+	n := &named{name: name}
+	n.level.Store(unset)
+
+	return n
+}
+
+// Named returns the logger of the component, e.g. "db", writing to the logger sink.
+// Statements are logged with the name as "logger" field, and the level of the logger
+// can be changed at runtime. Until it is set, the logger inherits the global level.
+func Named(name string) *named {
+	if name == "" {
+		return root
+	}
+
+	if n, ok := lookup(name); ok {
+		return n
+	}
+
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	if n, ok := loggers[name]; ok {
+		return n
+	}
+
+	n := newNamed(name)
+	loggers[name] = n
+
+	return n
+}
+
+// lookup returns the named logger, if it exists.
+func lookup(name string) (*named, bool) {
+	namedMu.RLock()
+	defer namedMu.RUnlock()
+
+	n, ok := loggers[name]
+
+	return n, ok
+}
+
+// SetLevel is setting the global level, it is the level of the package functions
+// and of the named loggers without a level. Until it is set, the global level is info
+// for the logger sink built by the package, and the level of any other logger sink.
+func SetLevel(level Level) {
+	root.SetLevel(level)
+}
+
+// ResetLevel is resetting the global level to the level of the logger sink.
+func ResetLevel() {
+	root.ResetLevel()
+}
+
+// GetLevel returns the global level.
+func GetLevel() Level {
+	return root.Level()
+}
+
+// Levels returns the levels of the named loggers.
+func Levels() map[string]Level {
+	namedMu.RLock()
+	defer namedMu.RUnlock()
+
+	levels := make(map[string]Level, len(loggers))
+	for name, n := range loggers {
+		levels[name] = n.Level()
+	}
+
+	return levels
+}
+
+// Name returns the name of the logger.
+func (n *named) Name() string {
+	return n.name
+}
+
+// Level returns the level of the logger.
+func (n *named) Level() Level {
+	if v := n.level.Load(); v != unset {
+		return Level(v)
+	}
+
+	if n == root {
+		return defaultLevel()
+	}
+
+	return root.Level()
+}
+
+// defaultLevel returns the global level until it is set, it is info for the logger sink
+// built by the package, and the lowest level enabled by any other logger sink.
+func defaultLevel() Level {
+	if LogSink == defaultSink && defaultSink.load() == packageSink {
+		return InfoLevel
+	}
+
+	for level := DebugLevel; level < FatalLevel; level++ {
+		if enabled(LogSink, level) {
+			return level
+		}
+	}
+
+	return FatalLevel
+}
+
+// SetLevel is setting the level of the logger.
+func (n *named) SetLevel(level Level) {
+	n.level.Store(int32(level))
+	syncSinkLevel()
+}
+
+// ResetLevel is resetting the level of the logger to the global level.
+func (n *named) ResetLevel() {
+	n.level.Store(unset)
+	syncSinkLevel()
+}
+
+// syncSinkLevel is setting the level of the logger sink to the lowest of the global
+// and the named levels, so that the direct users of the sink are filtered by the levels
+// while the named loggers with a lower level are filtering their statements themselves.
+func syncSinkLevel() {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	level := root.Level()
+	for _, n := range loggers {
+		level = min(level, n.Level())
+	}
+
+	sinkLevel.SetLevel(zapcore.Level(level))
+}
+
+// Enabled reports whether statements of the level are written.
+func (n *named) Enabled(level Level) bool {
+	return level >= n.Level() && enabled(LogSink, level)
+}
+
+// allows reports whether a statement of the level is passed to the logger sink,
+// panic and fatal statements are always passed to panic or exit.
+func (n *named) allows(level Level) bool {
+	return level >= PanicLevel || level >= n.Level()
+}
+
+func (n *named) sink() Logger {
+	if n == root {
+		return LogSink
+	}
+
+	return With(LogSink, "logger", n.name)
+}
+
+func (n *named) logf(level Level, format string, v []interface{}) {
+	n.write(level, format, func() string { return fmt.Sprintf(format, v...) })
+}
+
+func (n *named) log(level Level, msg string, keysAndValues ...interface{}) {
+	n.write(level, msg, func() string { return msg }, keysAndValues...)
+}
+
+func (n *named) write(level Level, id string, msg func() string, keysAndValues ...interface{}) {
+	if n.allows(level) {
+		writeTo(n.sink(), level, id, msg, keysAndValues...)
+	}
+}
+
+// Noticef is logging a notice statement.
+func (n *named) Noticef(format string, v ...interface{}) {
+	n.logf(InfoLevel, format, v)
+}
+
+// Infof is logging an info statement.
+func (n *named) Infof(format string, v ...interface{}) {
+	n.logf(InfoLevel, format, v)
+}
+
+// Warnf is logging a warning statement.
+func (n *named) Warnf(format string, v ...interface{}) {
+	n.logf(WarnLevel, format, v)
+}
+
+// Fatalf is logging a fatal error.
+func (n *named) Fatalf(format string, v ...interface{}) {
+	n.logf(FatalLevel, format, v)
+}
+
+// Errorf is logging an error.
+func (n *named) Errorf(format string, v ...interface{}) {
+	n.logf(ErrorLevel, format, v)
+}
+
+// Debugf is logging a debug statement.
+func (n *named) Debugf(format string, v ...interface{}) {
+	n.logf(DebugLevel, format, v)
+}
+
+// Tracef is logging a trace statement.
+func (n *named) Tracef(format string, v ...interface{}) {
+	n.logf(DebugLevel, format, v)
+}
+
+// Panicf is logging a panic statement.
+func (n *named) Panicf(format string, v ...interface{}) {
+	n.logf(PanicLevel, format, v)
+}
+
+// Printf is logging a printf statement.
+func (n *named) Printf(format string, v ...interface{}) {
+	n.logf(InfoLevel, format, v)
+}
+
+// Debugw is logging a debug statement with context.
+func (n *named) Debugw(msg string, keysAndValues ...interface{}) {
+	n.log(DebugLevel, msg, keysAndValues...)
+}
+
+// Infow is logging an info statement with context.
+func (n *named) Infow(msg string, keysAndValues ...interface{}) {
+	n.log(InfoLevel, msg, keysAndValues...)
+}
+
+// Warnw is logging a warning statement with context.
+func (n *named) Warnw(msg string, keysAndValues ...interface{}) {
+	n.log(WarnLevel, msg, keysAndValues...)
+}
+
+// Errorw is logging an error statement with context.
+func (n *named) Errorw(msg string, keysAndValues ...interface{}) {
+	n.log(ErrorLevel, msg, keysAndValues...)
+}
+
+// DPanicw is logging a debug panic statement with context.
+func (n *named) DPanicw(msg string, keysAndValues ...interface{}) {
+	n.log(DPanicLevel, msg, keysAndValues...)
+}
+
+// Panicw is logging a panic statement with context.
+func (n *named) Panicw(msg string, keysAndValues ...interface{}) {
+	n.log(PanicLevel, msg, keysAndValues...)
+}
+
+// Fatalw is logging a fatal statement with context.
+func (n *named) Fatalw(msg string, keysAndValues ...interface{}) {
+	n.log(FatalLevel, msg, keysAndValues...)
+}
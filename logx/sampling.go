@@ -0,0 +1,170 @@
+package logx
+
+import (
+	"slices"
+	"time"
+)
+
+// maxKeys is the maximum number of message keys with a window,
+// expired windows are removed first, then the window ending first.
+const maxKeys = 1024
+
+// Sampling is logging the first statements with the same level and message
+// in each interval, and then every Thereafter statement.
+type Sampling struct {
+	// Interval is the interval of the sampling.
+	Interval time.Duration
+	// First is the number of statements logged in each interval.
+	First int
+	// Thereafter is logging every nth statement after the first, zero drops them.
+	Thereafter int
+}
+
+// RateLimit is limiting the statements with the same level and message in each interval,
+// the number of dropped statements is added to the next logged statement as "dropped" field.
+type RateLimit struct {
+	// Interval is the interval of the limit.
+	Interval time.Duration
+	// Limit is the number of statements logged in each interval.
+	Limit int
+}
+
+type key struct {
+	level Level
+	msg   string
+}
+
+type window struct {
+	reset   time.Time
+	n       int
+	dropped int
+}
+
+type windows map[key]*window
+
+// next returns the window of the key, a new window is started when the previous is expired.
+func (w windows) next(k key, now time.Time, interval time.Duration) *window {
+	c, ok := w[k]
+	if ok && now.Before(c.reset) {
+		c.n++
+		return c
+	}
+
+	if !ok && len(w) >= maxKeys {
+		w.evict(now)
+	}
+
+	dropped := 0
+	if ok {
+		dropped = c.dropped
+	}
+
+	c = &window{reset: now.Add(interval), n: 1, dropped: dropped}
+	w[k] = c
+
+	return c
+}
+
+// evict is removing the expired windows, or the window ending first if none is expired.
+func (w windows) evict(now time.Time) {
+	var (
+		oldest key
+		reset  time.Time
+	)
+
+	for k, c := range w {
+		if !now.Before(c.reset) {
+			delete(w, k)
+			continue
+		}
+
+		if reset.IsZero() || c.reset.Before(reset) {
+			oldest, reset = k, c.reset
+		}
+	}
+
+	if len(w) >= maxKeys {
+		delete(w, oldest)
+	}
+}
+
+type sampler struct {
+	opts    Sampling
+	windows windows
+}
+
+func (s *sampler) allow(k key, now time.Time) bool {
+	c := s.windows.next(k, now, s.opts.Interval)
+	if c.n <= s.opts.First {
+		return true
+	}
+
+	return s.opts.Thereafter > 0 && (c.n-s.opts.First)%s.opts.Thereafter == 0
+}
+
+type limiter struct {
+	opts    RateLimit
+	windows windows
+}
+
+// allow reports whether the statement is logged, and the number of statements dropped before.
+func (l *limiter) allow(k key, now time.Time) (bool, int) {
+	c := l.windows.next(k, now, l.opts.Interval)
+	if c.n > l.opts.Limit {
+		c.dropped++
+		return false, 0
+	}
+
+	dropped := c.dropped
+	c.dropped = 0
+
+	return true, dropped
+}
+
+type record struct {
+	level         Level
+	msg           string
+	keysAndValues []interface{}
+}
+
+// deduper is collapsing repeated identical statements in the window,
+// the first statement is logged, and the repeats are logged as one statement
+// with the "repeated" field when a different statement is logged or the window ends.
+type deduper struct {
+	window   time.Duration
+	last     *record
+	id       string
+	started  time.Time
+	repeated int
+	timer    *time.Timer
+}
+
+// add returns whether the statement is logged, and the pending repeats to log before it.
+func (d *deduper) add(r record, id string, now time.Time) (bool, *record) {
+	if d.last != nil && id == d.id && now.Sub(d.started) < d.window {
+		d.repeated++
+		return false, nil
+	}
+
+	pending := d.flush()
+
+	d.last, d.id, d.started = &r, id, now
+
+	return true, pending
+}
+
+// flush returns the statement of the pending repeats, if any.
+func (d *deduper) flush() *record {
+	last, repeated := d.last, d.repeated
+	d.last, d.id, d.repeated = nil, "", 0
+
+	if last == nil || repeated == 0 {
+		return nil
+	}
+
+	return &record{
+		level:         last.level,
+		msg:           last.msg,
+		keysAndValues: append(slices.Clip(last.keysAndValues), "repeated", repeated),
+	}
+}
@@ -0,0 +1,26 @@
+package logx
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindows_Evict(t *testing.T) {
+	w := windows{}
+	now := time.Now()
+
+	for i := range maxKeys {
+		w.next(key{level: InfoLevel, msg: fmt.Sprint(i)}, now.Add(time.Duration(i)), time.Minute)
+	}
+
+	w.next(key{level: InfoLevel, msg: "new"}, now, time.Minute)
+	assert.Len(t, w, maxKeys)
+	assert.NotContains(t, w, key{level: InfoLevel, msg: "0"})
+	assert.Contains(t, w, key{level: InfoLevel, msg: "new"})
+
+	w.next(key{level: InfoLevel, msg: "expired"}, now.Add(2*time.Minute), time.Minute)
+	assert.Len(t, w, 1)
+}
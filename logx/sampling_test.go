@@ -0,0 +1,102 @@
+package logx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/logx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newObserved(opts ...logx.Opt) (logx.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	return logx.NewLogger(append([]logx.Opt{logx.WithLogger(zap.New(core))}, opts...)...), logs
+}
+
+func TestNewLogger_Sampling(t *testing.T) {
+	log, logs := newObserved(logx.WithSampling(time.Minute, 2, 3))
+
+	for i := range 10 {
+		log.Errorw("boom", "i", i)
+		log.Infof("request %d", i)
+	}
+
+	log.Warnw("other")
+	assert.Panics(t, func() { log.Panicw("boom") })
+
+	assert.Equal(t, 4, logs.FilterMessage("boom").FilterLevelExact(zapcore.ErrorLevel).Len())
+	assert.Equal(t, 4, logs.FilterLevelExact(zapcore.InfoLevel).Len())
+	assert.Equal(t, 1, logs.FilterMessage("other").Len())
+	assert.Equal(t, 1, logs.FilterLevelExact(zapcore.PanicLevel).Len())
+
+	var got []int64
+	for _, e := range logs.FilterMessage("boom").FilterLevelExact(zapcore.ErrorLevel).AllUntimed() {
+		got = append(got, e.ContextMap()["i"].(int64))
+	}
+
+	assert.Equal(t, []int64{0, 1, 4, 7}, got)
+}
+
+func TestNewLogger_SamplingFormat(t *testing.T) {
+	log, logs := newObserved(logx.WithSampling(time.Minute, 1, 0))
+	fields := logx.With(log, "a", 1)
+
+	for i := range 5 {
+		fields.Infof("request %d", i)
+	}
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "request 0", entries[0].Message)
+}
+
+func TestNewLogger_RateLimit(t *testing.T) {
+	log, logs := newObserved(logx.WithRateLimit(2, 50*time.Millisecond))
+
+	for range 5 {
+		log.Errorw("boom")
+	}
+
+	assert.Equal(t, 2, logs.Len())
+
+	time.Sleep(60 * time.Millisecond)
+	log.Errorw("boom")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	assert.Equal(t, map[string]interface{}{"dropped": int64(3)}, entries[2].ContextMap())
+}
+
+func TestNewLogger_Dedup(t *testing.T) {
+	log, logs := newObserved(logx.WithDedup(time.Minute))
+
+	for range 3 {
+		log.Errorw("boom", "code", 1)
+	}
+
+	log.Errorw("boom", "code", 2)
+	log.Infof("done")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4)
+	assert.Equal(t, map[string]interface{}{"code": int64(1)}, entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"code": int64(1), "repeated": int64(2)}, entries[1].ContextMap())
+	assert.Equal(t, map[string]interface{}{"code": int64(2)}, entries[2].ContextMap())
+	assert.Equal(t, "done", entries[3].Message)
+}
+
+func TestNewLogger_DedupWindow(t *testing.T) {
+	log, logs := newObserved(logx.WithDedup(20 * time.Millisecond))
+
+	log.Warnw("boom")
+	log.Warnw("boom")
+
+	assert.Eventually(t, func() bool { return logs.Len() == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, map[string]interface{}{"repeated": int64(1)}, logs.AllUntimed()[1].ContextMap())
+}
@@ -0,0 +1,118 @@
+package logx
+
+import (
+	"sync/atomic"
+)
+
+// SetSink is setting the logger of the logger sink, it is safe to call while statements
+// are logged concurrently. The returned function restores the previous logger.
+func SetSink(l Logger) func() {
+	prev := defaultSink.log.Swap(&l)
+
+	return func() {
+		defaultSink.log.Store(prev)
+	}
+}
+
+var (
+	_ Logger = (*sink)(nil)
+	_ writer = (*sink)(nil)
+)
+
+// sink is forwarding the statements to the logger set with SetSink.
+type sink struct {
+	log atomic.Pointer[Logger]
+}
+
+func (s *sink) load() Logger {
+	return *s.log.Load()
+}
+
+// Enabled reports whether statements of the level are written.
+func (s *sink) Enabled(level Level) bool {
+	return enabled(s.load(), level)
+}
+
+func (s *sink) write(level Level, id string, msg func() string, keysAndValues ...interface{}) {
+	writeTo(s.load(), level, id, msg, keysAndValues...)
+}
+
+// Noticef is logging a notice statement.
+func (s *sink) Noticef(format string, v ...interface{}) {
+	s.load().Noticef(format, v...)
+}
+
+// Infof is logging an info statement.
+func (s *sink) Infof(format string, v ...interface{}) {
+	s.load().Infof(format, v...)
+}
+
+// Warnf is logging a warning statement.
+func (s *sink) Warnf(format string, v ...interface{}) {
+	s.load().Warnf(format, v...)
+}
+
+// Fatalf is logging a fatal error.
+func (s *sink) Fatalf(format string, v ...interface{}) {
+	s.load().Fatalf(format, v...)
+}
+
+// Errorf is logging an error.
+func (s *sink) Errorf(format string, v ...interface{}) {
+	s.load().Errorf(format, v...)
+}
+
+// Debugf is logging a debug statement.
+func (s *sink) Debugf(format string, v ...interface{}) {
+	s.load().Debugf(format, v...)
+}
+
+// Tracef is logging a trace statement.
+func (s *sink) Tracef(format string, v ...interface{}) {
+	s.load().Tracef(format, v...)
+}
+
+// Panicf is logging a panic statement.
+func (s *sink) Panicf(format string, v ...interface{}) {
+	s.load().Panicf(format, v...)
+}
+
+// Printf is logging a printf statement.
+func (s *sink) Printf(format string, v ...interface{}) {
+	s.load().Printf(format, v...)
+}
+
+// Debugw is logging a debug statement with context.
+func (s *sink) Debugw(msg string, keysAndValues ...interface{}) {
+	s.load().Debugw(msg, keysAndValues...)
+}
+
+// Infow is logging an info statement with context.
+func (s *sink) Infow(msg string, keysAndValues ...interface{}) {
+	s.load().Infow(msg, keysAndValues...)
+}
+
+// Warnw is logging a warning statement with context.
+func (s *sink) Warnw(msg string, keysAndValues ...interface{}) {
+	s.load().Warnw(msg, keysAndValues...)
+}
+
+// Errorw is logging an error statement with context.
+func (s *sink) Errorw(msg string, keysAndValues ...interface{}) {
+	s.load().Errorw(msg, keysAndValues...)
+}
+
+// DPanicw is logging a debug panic statement with context.
+func (s *sink) DPanicw(msg string, keysAndValues ...interface{}) {
+	s.load().DPanicw(msg, keysAndValues...)
+}
+
+// Panicw is logging a panic statement with context.
+func (s *sink) Panicw(msg string, keysAndValues ...interface{}) {
+	s.load().Panicw(msg, keysAndValues...)
+}
+
+// Fatalw is logging a fatal statement with context.
+func (s *sink) Fatalw(msg string, keysAndValues ...interface{}) {
+	s.load().Fatalw(msg, keysAndValues...)
+}
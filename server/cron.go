@@ -0,0 +1,261 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidCron is returned when a cron expression cannot be parsed.
+	ErrInvalidCron = errors.New("invalid cron expression")
+	// ErrInvalidInterval is returned when a job is scheduled with an interval that is not positive.
+	ErrInvalidInterval = errors.New("invalid interval")
+)
+
+// Schedule is returning the next time a job should run.
+type Schedule interface {
+	// Next returns the next time after the given time.
+	Next(time.Time) time.Time
+}
+
+type every struct {
+	interval time.Duration
+}
+
+// Every returns a schedule running at a fixed interval,
+// the scheduler rejects intervals that are not positive with ErrInvalidInterval.
+func Every(interval time.Duration) Schedule {
+	return every{interval: interval}
+}
+
+// Next returns the next time after the given time.
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(e.interval)
+}
+
+// String returns the schedule as a descriptor.
+func (e every) String() string {
+	return "@every " + e.interval.String()
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minutes = bounds{0, 59}
+	hours   = bounds{0, 23}
+	days    = bounds{1, 31}
+	months  = bounds{1, 12}
+	// weekdays allows 7 as Sunday.
+	weekdays = bounds{0, 7}
+)
+
+// cron is a schedule of a cron expression with the fields
+// minute, hour, day of month, month and day of week.
+type cron struct {
+	expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool
+	anyDow bool
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron is parsing a standard cron expression with five fields,
+// supporting lists, ranges, steps, and the descriptors @yearly, @annually,
+// @monthly, @weekly, @daily, @midnight, @hourly and @every <duration>.
+func ParseCron(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+
+	if interval, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || d <= 0 {
+			return nil, NewServerError(fmt.Errorf("%w: %q", ErrInvalidCron, expr))
+		}
+
+		return Every(d), nil
+	}
+
+	spec := expr
+	if d, ok := descriptors[expr]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, NewServerError(fmt.Errorf("%w: %q expected 5 fields", ErrInvalidCron, expr))
+	}
+
+	c := &cron{expr: expr}
+
+	var err error
+
+	for i, f := range []struct {
+		field  string
+		bounds bounds
+		bits   *uint64
+	}{
+		{fields[0], minutes, &c.minute},
+		{fields[1], hours, &c.hour},
+		{fields[2], days, &c.dom},
+		{fields[3], months, &c.month},
+		{fields[4], weekdays, &c.dow},
+	} {
+		*f.bits, err = parseField(f.field, f.bounds)
+		if err != nil {
+			return nil, NewServerError(fmt.Errorf("%w: %q field %d: %w", ErrInvalidCron, expr, i+1, err))
+		}
+	}
+
+	// Sunday is 0 and 7.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	c.anyDom = fields[2] == "*" || fields[2] == "?"
+	c.anyDow = fields[4] == "*" || fields[4] == "?"
+
+	return c, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+
+	for part := range strings.SplitSeq(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepStr)
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+
+			step = s
+		}
+
+		lo, hi := b.min, b.max
+
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.Contains(rng, "-"):
+			loStr, hiStr, _ := strings.Cut(rng, "-")
+
+			var err error
+			if lo, err = parseValue(loStr, b); err != nil {
+				return 0, err
+			}
+
+			if hi, err = parseValue(hiStr, b); err != nil {
+				return 0, err
+			}
+
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			v, err := parseValue(rng, b)
+			if err != nil {
+				return 0, err
+			}
+
+			lo = v
+			if !hasStep {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, b.min, b.max)
+	}
+
+	return v, nil
+}
+
+// maxYears is the number of years to search for the next time.
+const maxYears = 5
+
+// Next returns the next time after the given time.
+func (c *cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxYears, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay is matching the day of month and the day of week,
+// if both are restricted one of them has to match.
+func (c *cron) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// String returns the cron expression.
+func (c *cron) String() string {
+	return c.expr
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	from := time.Date(2026, time.January, 1, 12, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.January, 1, 12, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.January, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 9-17 * * *", time.Date(2026, time.January, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * *", time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1", time.Date(2026, time.January, 5, 8, 30, 0, 0, time.UTC)},
+		{"30 8 * * 7", time.Date(2026, time.January, 4, 8, 30, 0, 0, time.UTC)},
+		{"0 0 15 * 1", time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		{"5,10 1 * 3 *", time.Date(2026, time.March, 1, 1, 5, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, time.January, 1, 13, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 1m30s", time.Date(2026, time.January, 1, 12, 31, 45, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			s, err := ParseCron(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.next, s.Next(from))
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every",
+		"@every -1s",
+	} {
+		_, err := ParseCron(expr)
+		require.ErrorIs(t, err, ErrInvalidCron, expr)
+	}
+}
+
+func TestParseCronNever(t *testing.T) {
+	s, err := ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestEvery(t *testing.T) {
+	now := time.Now()
+	s := Every(time.Second)
+
+	assert.Equal(t, now.Add(time.Second), s.Next(now))
+	assert.Equal(t, "@every 1s", s.(interface{ String() string }).String())
+}
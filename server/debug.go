@@ -2,12 +2,21 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"maps"
+	"net"
 	"net/http"
 	"net/http/pprof"
+	"sync"
+	"time"
 )
 
-var _ Listener = (*debug)(nil)
+var (
+	_ Listener = (*debug)(nil)
+	_ Stopper  = (*debug)(nil)
+	_ Reloader = (*debug)(nil)
+)
 
 // DefaultRoues are the default routes for the debug listener.
 var DefaultRoues = map[string]http.Handler{
@@ -22,6 +31,10 @@ type debug struct {
 	opts    *DebugOpts
 	mux     *http.ServeMux
 	handler *http.Server
+
+	mu    sync.Mutex
+	addr  net.Addr
+	certs *Certificates
 }
 
 // DebugOpts are the options for the debug listener.
@@ -30,6 +43,24 @@ type DebugOpts struct {
 	Addr string
 	// Routes configures the routes for the debug listener.
 	Routes map[string]http.Handler
+	// TLSConfig is the TLS config to serve TLS with.
+	TLSConfig *tls.Config
+	// CertFile is the certificate file to serve TLS with.
+	CertFile string
+	// KeyFile is the key file of the certificate.
+	KeyFile string
+	// ClientCAFile is the CA file to verify the client certificates with.
+	ClientCAFile string
+	// CertReloadInterval is the interval to check the certificate files for changes.
+	CertReloadInterval time.Duration
+}
+
+func (o *DebugOpts) tls() bool {
+	return o.TLSConfig != nil || o.CertFile != "" || o.ClientCAFile != ""
+}
+
+func (o *DebugOpts) files() bool {
+	return o.CertFile != "" || o.ClientCAFile != ""
 }
 
 // Configure is a method that configures the debug options.
@@ -42,8 +73,9 @@ func (o *DebugOpts) Configure(opts ...DebugOpt) {
 // DefaultOpts returns the default options for the debug listener.
 func DefaultOpts() *DebugOpts {
 	return &DebugOpts{
-		Addr:   ":8443",
-		Routes: map[string]http.Handler{},
+		Addr:               ":8443",
+		Routes:             map[string]http.Handler{},
+		CertReloadInterval: DefaultCertReloadInterval,
 	}
 }
 
@@ -71,17 +103,72 @@ func NewDebug(opts ...DebugOpt) *debug {
 }
 
 // Start is a method that starts the debug listener.
+// The listener is ready once the address is bound.
 func (d *debug) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
 	return func() error {
-		// noop, call to be ready
-		ready()
+		var lc net.ListenConfig
 
-		if err := d.handler.ListenAndServe(); err != nil {
+		ln, err := lc.Listen(ctx, "tcp", d.opts.Addr)
+		if err != nil {
 			return err
 		}
 
+		d.mu.Lock()
+		d.addr = ln.Addr()
+		d.mu.Unlock()
+
+		if !d.opts.tls() {
+			ready()
+
+			return d.serve(d.handler.Serve(ln))
+		}
+
+		certs, err := NewCertificates(d.opts.CertFile, d.opts.KeyFile, d.opts.ClientCAFile, d.opts.TLSConfig)
+		if err != nil {
+			_ = ln.Close()
+			return err
+		}
+
+		d.mu.Lock()
+		d.certs = certs
+		d.mu.Unlock()
+
+		d.handler.TLSConfig = certs.Config()
+
+		if d.opts.files() {
+			run(func() error { return certs.Watch(ctx, d.opts.CertReloadInterval) })
+		}
+
+		ready()
+
+		return d.serve(d.handler.ServeTLS(ln, "", ""))
+	}
+}
+
+func (d *debug) serve(err error) error {
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Reload is a method that reloads the certificates of the debug listener.
+func (d *debug) Reload(ctx context.Context) error {
+	d.mu.Lock()
+	certs := d.certs
+	d.mu.Unlock()
+
+	if certs == nil {
 		return nil
 	}
+
+	return certs.Reload()
+}
+
+// Stop is a method that gracefully shuts down the debug listener.
+func (d *debug) Stop(ctx context.Context) error {
+	return d.handler.Shutdown(ctx)
 }
 
 // WithAddr is adding this status addr as an option.
@@ -91,6 +178,37 @@ func WithAddr(addr string) DebugOpt {
 	}
 }
 
+// WithTLS is serving TLS with the certificate and key files as an option.
+// The certificate is reloaded when the files change on disk.
+func WithTLS(certFile, keyFile string) DebugOpt {
+	return func(opts *DebugOpts) {
+		opts.CertFile = certFile
+		opts.KeyFile = keyFile
+	}
+}
+
+// WithTLSConfig is serving TLS with the config as an option.
+func WithTLSConfig(cfg *tls.Config) DebugOpt {
+	return func(opts *DebugOpts) {
+		opts.TLSConfig = cfg
+	}
+}
+
+// WithClientCA is requiring client certificates verified by the CA file as an option.
+// The CA is reloaded when the file changes on disk.
+func WithClientCA(caFile string) DebugOpt {
+	return func(opts *DebugOpts) {
+		opts.ClientCAFile = caFile
+	}
+}
+
+// WithCertReloadInterval is setting the interval to check the certificate files for changes.
+func WithCertReloadInterval(interval time.Duration) DebugOpt {
+	return func(opts *DebugOpts) {
+		opts.CertReloadInterval = interval
+	}
+}
+
 // WithPprof is adding this pprof routes as an option.
 func WithPprof() DebugOpt {
 	return func(opts *DebugOpts) {
@@ -107,6 +225,24 @@ func WithPrometheusHandler(handler http.Handler) DebugOpt {
 	}
 }
 
+// WithRoute is adding a route with the handler as an option.
+func WithRoute(route string, handler http.Handler) DebugOpt {
+	return func(opts *DebugOpts) {
+		opts.Routes[route] = handler
+	}
+}
+
+// WithHealth is adding the health, liveness and readiness routes as an option.
+func WithHealth(health *Health) DebugOpt {
+	return func(opts *DebugOpts) {
+		maps.Copy(opts.Routes, map[string]http.Handler{
+			"/healthz": health.HealthzHandler(),
+			"/livez":   health.LivenessHandler(),
+			"/readyz":  health.ReadinessHandler(),
+		})
+	}
+}
+
 func configureMux(d *debug) {
 	for route, handler := range d.opts.Routes {
 		d.mux.Handle(route, handler)
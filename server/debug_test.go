@@ -0,0 +1,19 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx, WithShutdownTimeout(time.Second))
+
+	srv.Listen(NewDebug(WithAddr("127.0.0.1:0"), WithPprof()), true)
+	srv.Listen(&canceler{cancel: cancel}, true)
+
+	require.NoError(t, srv.Wait())
+}
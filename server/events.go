@@ -0,0 +1,130 @@
+package server
+
+import (
+	"time"
+
+	"github.com/katallaxie/pkg/logx"
+)
+
+// EventType is the type of a lifecycle event.
+type EventType int
+
+const (
+	// EventStart is emitted when a listener is started or restarted.
+	EventStart EventType = iota
+	// EventReady is emitted when a listener is ready.
+	EventReady
+	// EventError is emitted when a listener or one of its routines failed.
+	EventError
+	// EventStop is emitted when a listener is stopped during shutdown.
+	EventStop
+	// EventReload is emitted when a listener is reloaded.
+	EventReload
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventStart:
+		return "start"
+	case EventReady:
+		return "ready"
+	case EventError:
+		return "error"
+	case EventStop:
+		return "stop"
+	case EventReload:
+		return "reload"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a lifecycle event of a listener.
+type Event struct {
+	// Type is the type of the event.
+	Type EventType
+	// Listener is the name of the listener.
+	Listener string
+	// Time is the time the event occurred.
+	Time time.Time
+	// Err is the error of the event, if any.
+	Err error
+}
+
+// Hooks are functions called on the lifecycle events of the listeners.
+// The hooks are called synchronously from the routines of the server,
+// so they should not block and must be safe for concurrent use.
+type Hooks struct {
+	// OnStart is called when a listener is started or restarted.
+	OnStart func(Event)
+	// OnReady is called when a listener is ready.
+	OnReady func(Event)
+	// OnError is called when a listener or one of its routines failed.
+	OnError func(Event)
+	// OnStop is called when a listener is stopped during shutdown.
+	OnStop func(Event)
+	// OnReload is called when a listener is reloaded.
+	OnReload func(Event)
+}
+
+func (h Hooks) call(e Event) {
+	var fn func(Event)
+
+	switch e.Type {
+	case EventStart:
+		fn = h.OnStart
+	case EventReady:
+		fn = h.OnReady
+	case EventError:
+		fn = h.OnError
+	case EventStop:
+		fn = h.OnStop
+	case EventReload:
+		fn = h.OnReload
+	}
+
+	if fn != nil {
+		fn(e)
+	}
+}
+
+// LogHooks returns the hooks logging the lifecycle events to logx,
+// they are added with WithHooks(LogHooks()).
+func LogHooks() Hooks {
+	return Hooks{
+		OnStart: func(e Event) {
+			logx.Infow("listener starting", "listener", e.Listener, "event", e.Type.String())
+		},
+		OnReady: func(e Event) {
+			logx.Infow("listener ready", "listener", e.Listener, "event", e.Type.String())
+		},
+		OnError: func(e Event) {
+			logx.Errorw("listener failed", "listener", e.Listener, "event", e.Type.String(), "error", e.Err)
+		},
+		OnStop: func(e Event) {
+			if e.Err != nil {
+				logx.Errorw("listener stopped", "listener", e.Listener, "event", e.Type.String(), "error", e.Err)
+				return
+			}
+
+			logx.Infow("listener stopped", "listener", e.Listener, "event", e.Type.String())
+		},
+		OnReload: func(e Event) {
+			if e.Err != nil {
+				logx.Errorw("listener reload failed", "listener", e.Listener, "event", e.Type.String(), "error", e.Err)
+				return
+			}
+
+			logx.Infow("listener reloaded", "listener", e.Listener, "event", e.Type.String())
+		},
+	}
+}
+
+func (s *server) emit(t EventType, l *listener, err error) {
+	e := Event{Type: t, Listener: l.name, Time: time.Now(), Err: err}
+
+	for _, h := range s.opts.Hooks {
+		h.call(e)
+	}
+}
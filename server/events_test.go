@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordHooks(r *recorder) Hooks {
+	record := func(e Event) { r.record(e.Listener + ":" + e.Type.String()) }
+
+	return Hooks{OnStart: record, OnReady: record, OnError: record, OnStop: record}
+}
+
+func TestHooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+
+	r := &recorder{}
+	srv, _ := WithContext(ctx, WithoutHooks(), WithHooks(recordHooks(r)))
+
+	stopped := []string{}
+	srv.ListenWith(&stopper{name: "stopper", stopped: &stopped, done: make(chan struct{})}, WithName("stopper"), WithWait())
+	srv.ListenWith(&canceler{cancel: cancel}, WithName("canceler"))
+
+	require.NoError(t, srv.Wait())
+
+	assert.Equal(t, []string{
+		"stopper:start",
+		"stopper:ready",
+		"canceler:start",
+		"canceler:ready",
+		"canceler:stop",
+		"stopper:stop",
+	}, r.events)
+}
+
+func TestHooksError(t *testing.T) {
+	r := &recorder{}
+	srv, _ := WithContext(t.Context(), WithoutHooks(), WithHooks(recordHooks(r)))
+
+	srv.ListenWith(&Unimplemented{}, WithName("unimplemented"))
+
+	require.ErrorIs(t, srv.Wait(), ErrUnimplemented)
+	assert.Equal(t, []string{"unimplemented:start", "unimplemented:error", "unimplemented:stop"}, r.events)
+}
+
+func TestEventTypeString(t *testing.T) {
+	assert.Equal(t, "start", EventStart.String())
+	assert.Equal(t, "ready", EventReady.String())
+	assert.Equal(t, "error", EventError.String())
+	assert.Equal(t, "stop", EventStop.String())
+	assert.Equal(t, "reload", EventReload.String())
+	assert.Equal(t, "unknown", EventType(-1).String())
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrCycle is returned when the dependencies of the listeners have a cycle.
+	ErrCycle = errors.New("dependency cycle")
+	// ErrUnknownDependency is returned when a listener depends on an unknown listener.
+	ErrUnknownDependency = errors.New("unknown dependency")
+	// ErrAmbiguousDependency is returned when a listener depends on a name used by multiple listeners.
+	ErrAmbiguousDependency = errors.New("ambiguous dependency")
+)
+
+// resolve is resolving the dependencies of the listeners.
+// A listener waiting to be ready is an implicit dependency
+// of all the listeners added after it.
+func (ls listeners) resolve() error {
+	names := make(map[string]listeners, len(ls))
+	for _, l := range ls {
+		names[l.name] = append(names[l.name], l)
+	}
+
+	for i, l := range ls {
+		l.deps = make(listeners, 0, len(l.opts.DependsOn))
+
+		for _, prev := range ls[:i] {
+			if prev.opts.Wait {
+				l.deps = append(l.deps, prev)
+			}
+		}
+
+		for _, name := range l.opts.DependsOn {
+			deps, ok := names[name]
+			if !ok {
+				return NewServerError(fmt.Errorf("%s depends on %q: %w", l.name, name, ErrUnknownDependency))
+			}
+
+			if len(deps) > 1 {
+				return NewServerError(fmt.Errorf("%s depends on %q: %w", l.name, name, ErrAmbiguousDependency))
+			}
+
+			l.deps = append(l.deps, deps[0])
+		}
+	}
+
+	return nil
+}
+
+// sort is sorting the listeners in topological order of their dependencies,
+// listeners without dependencies between them keep the order they were added in.
+func (ls listeners) sort() (listeners, error) {
+	if err := ls.resolve(); err != nil {
+		return nil, err
+	}
+
+	indegree := make(map[*listener]int, len(ls))
+	dependents := make(map[*listener]listeners, len(ls))
+
+	for _, l := range ls {
+		for _, dep := range l.deps {
+			indegree[l]++
+			dependents[dep] = append(dependents[dep], l)
+		}
+	}
+
+	sorted := make(listeners, 0, len(ls))
+	for _, l := range ls {
+		if indegree[l] == 0 {
+			sorted = append(sorted, l)
+		}
+	}
+
+	for i := 0; i < len(sorted); i++ {
+		for _, l := range dependents[sorted[i]] {
+			if indegree[l]--; indegree[l] == 0 {
+				sorted = append(sorted, l)
+			}
+		}
+	}
+
+	if len(sorted) < len(ls) {
+		cycle := make([]string, 0, len(ls)-len(sorted))
+		for _, l := range ls {
+			if indegree[l] > 0 {
+				cycle = append(cycle, l.name)
+			}
+		}
+
+		return nil, NewServerError(fmt.Errorf("%w involving %s", ErrCycle, strings.Join(cycle, ", ")))
+	}
+
+	return sorted, nil
+}
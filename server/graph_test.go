@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(event string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) index(event string) int {
+	r.Lock()
+	defer r.Unlock()
+
+	for i, e := range r.events {
+		if e == event {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type job struct {
+	name     string
+	recorder *recorder
+}
+
+func (j *job) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		j.recorder.record(j.name)
+
+		return nil
+	}
+}
+
+type daemon struct {
+	name     string
+	recorder *recorder
+}
+
+func (s *daemon) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		s.recorder.record(s.name)
+		ready()
+		<-ctx.Done()
+
+		return nil
+	}
+}
+
+func TestDependsOn(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx)
+
+	r := &recorder{}
+	srv.ListenWith(&daemon{name: "http", recorder: r}, WithName("http"), WithDependsOn("db-migrator", "cache-warmer"))
+	srv.ListenWith(&job{name: "db-migrator", recorder: r}, WithName("db-migrator"))
+	srv.ListenWith(&daemon{name: "cache-warmer", recorder: r}, WithName("cache-warmer"))
+	srv.ListenWith(&readiness{health: srv.Health(), cancel: cancel}, WithDependsOn("http"))
+
+	require.NoError(t, srv.Wait())
+
+	assert.Less(t, r.index("db-migrator"), r.index("http"))
+	assert.Less(t, r.index("cache-warmer"), r.index("http"))
+}
+
+func TestDependsOnCycle(t *testing.T) {
+	srv, _ := WithContext(t.Context())
+
+	r := &recorder{}
+	srv.ListenWith(&daemon{name: "a", recorder: r}, WithName("a"), WithDependsOn("b"))
+	srv.ListenWith(&daemon{name: "b", recorder: r}, WithName("b"), WithDependsOn("a"))
+	srv.ListenWith(&daemon{name: "c", recorder: r}, WithName("c"))
+
+	err := srv.Wait()
+	require.ErrorIs(t, err, ErrCycle)
+	assert.Equal(t, "server: dependency cycle involving a, b", err.Error())
+	assert.Empty(t, r.events)
+}
+
+func TestDependsOnUnknown(t *testing.T) {
+	srv, _ := WithContext(t.Context())
+
+	srv.ListenWith(&daemon{name: "a", recorder: &recorder{}}, WithName("a"), WithDependsOn("b"))
+
+	require.ErrorIs(t, srv.Wait(), ErrUnknownDependency)
+}
+
+func TestDependsOnAmbiguous(t *testing.T) {
+	srv, _ := WithContext(t.Context())
+
+	srv.ListenWith(&daemon{name: "a", recorder: &recorder{}}, WithName("a"))
+	srv.ListenWith(&daemon{name: "a", recorder: &recorder{}}, WithName("a"))
+	srv.ListenWith(&daemon{name: "b", recorder: &recorder{}}, WithName("b"), WithDependsOn("a"))
+
+	require.ErrorIs(t, srv.Wait(), ErrAmbiguousDependency)
+}
+
+func TestListenersSort(t *testing.T) {
+	srv, _ := WithContext(t.Context())
+
+	srv.ListenWith(&Unimplemented{}, WithName("a"), WithDependsOn("c"))
+	srv.ListenWith(&Unimplemented{}, WithName("b"), WithWait())
+	srv.ListenWith(&Unimplemented{}, WithName("c"))
+	srv.ListenWith(&Unimplemented{}, WithName("d"))
+
+	sorted, err := srv.listeners.sort()
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, l := range sorted {
+		names = append(names, l.name)
+	}
+
+	assert.Equal(t, []string{"b", "c", "d", "a"}, names)
+}
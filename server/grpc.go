@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+var (
+	_ Listener = (*grpcListener)(nil)
+	_ Stopper  = (*grpcListener)(nil)
+)
+
+type grpcListener struct {
+	opts   *GRPCOpts
+	server *grpc.Server
+	health *health.Server
+
+	mu   sync.Mutex
+	addr net.Addr
+}
+
+// GRPCOpts are the options for the gRPC listener.
+type GRPCOpts struct {
+	// Addr is the address to listen on.
+	Addr string
+	// Listener is a pre-opened listener to serve on.
+	Listener net.Listener
+	// Health is registering the standard gRPC health service.
+	Health bool
+	// Reflection is registering the gRPC reflection service.
+	Reflection bool
+}
+
+// Configure is a method that configures the gRPC options.
+func (o *GRPCOpts) Configure(opts ...GRPCOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultGRPCOpts returns the default options for the gRPC listener.
+func DefaultGRPCOpts() *GRPCOpts {
+	return &GRPCOpts{
+		Addr:   ":9090",
+		Health: true,
+	}
+}
+
+// GRPCOpt is a function that configures the gRPC options.
+type GRPCOpt func(*GRPCOpts)
+
+// NewGRPC is a function that creates a new gRPC listener serving the server.
+// The services have to be registered with the server before starting it.
+func NewGRPC(srv *grpc.Server, opts ...GRPCOpt) *grpcListener {
+	options := DefaultGRPCOpts()
+	options.Configure(opts...)
+
+	g := new(grpcListener)
+	g.opts = options
+	g.server = srv
+
+	if options.Health {
+		g.health = health.NewServer()
+		g.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		healthpb.RegisterHealthServer(srv, g.health)
+	}
+
+	if options.Reflection {
+		reflection.Register(srv)
+	}
+
+	return g
+}
+
+// Start is a method that starts the gRPC listener.
+// The listener is ready once the address is bound,
+// and the health service reports the services as serving.
+func (g *grpcListener) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ln := g.opts.Listener
+
+		if ln == nil {
+			var lc net.ListenConfig
+
+			l, err := lc.Listen(ctx, "tcp", g.opts.Addr)
+			if err != nil {
+				return err
+			}
+
+			ln = l
+		}
+
+		g.mu.Lock()
+		g.addr = ln.Addr()
+		g.mu.Unlock()
+
+		g.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+
+		ready()
+
+		if err := g.server.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// Stop is a method that gracefully stops the gRPC listener,
+// the pending requests are canceled when the context expires.
+func (g *grpcListener) Stop(ctx context.Context) error {
+	if g.health != nil {
+		g.health.Shutdown()
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		g.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		g.server.Stop()
+		<-done
+	}
+
+	return nil
+}
+
+func (g *grpcListener) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	if g.health == nil {
+		return
+	}
+
+	g.health.SetServingStatus("", status)
+
+	for name := range g.server.GetServiceInfo() {
+		g.health.SetServingStatus(name, status)
+	}
+}
+
+// WithGRPCAddr is setting the address to listen on.
+func WithGRPCAddr(addr string) GRPCOpt {
+	return func(opts *GRPCOpts) {
+		opts.Addr = addr
+	}
+}
+
+// WithGRPCListener is serving on the pre-opened listener.
+func WithGRPCListener(ln net.Listener) GRPCOpt {
+	return func(opts *GRPCOpts) {
+		opts.Listener = ln
+	}
+}
+
+// WithGRPCReflection is registering the gRPC reflection service.
+func WithGRPCReflection() GRPCOpt {
+	return func(opts *GRPCOpts) {
+		opts.Reflection = true
+	}
+}
+
+// WithoutGRPCHealth is not registering the standard gRPC health service.
+func WithoutGRPCHealth() GRPCOpt {
+	return func(opts *GRPCOpts) {
+		opts.Health = false
+	}
+}
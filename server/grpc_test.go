@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCListener(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx, WithShutdownTimeout(time.Second))
+
+	g := NewGRPC(grpc.NewServer(), WithGRPCListener(lis), WithGRPCReflection())
+	srv.Listen(g, true)
+
+	assert.Contains(t, g.server.GetServiceInfo(), "grpc.health.v1.Health")
+	assert.Contains(t, g.server.GetServiceInfo(), "grpc.reflection.v1.ServerReflection")
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Wait() }()
+
+	require.Eventually(t, srv.Health().Ready, time.Second, time.Millisecond)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	resp, err = healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{Service: "grpc.health.v1.Health"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	cancel()
+	require.NoError(t, <-errs)
+}
+
+func TestGRPCListenerWithoutHealth(t *testing.T) {
+	g := NewGRPC(grpc.NewServer(), WithoutGRPCHealth())
+
+	assert.Nil(t, g.health)
+	assert.NotContains(t, g.server.GetServiceInfo(), "grpc.health.v1.Health")
+}
+
+func TestGRPCListenerStopTimeout(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+
+	g := NewGRPC(grpc.NewServer(), WithGRPCListener(lis))
+
+	done := make(chan error, 1)
+	go func() { done <- g.Start(t.Context(), func() {}, func(func() error) {})() }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// open a watch stream that never finishes to block the graceful stop.
+	stream, err := healthpb.NewHealthClient(conn).Watch(t.Context(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, g.Stop(ctx))
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/katallaxie/pkg/envx"
+)
+
+// ErrNotReady is returned when not all listeners are ready.
+var ErrNotReady = errors.New("listeners not ready")
+
+const (
+	// StatusOK is the status of a passing check.
+	StatusOK = "ok"
+	// StatusFailed is the status of a failing check.
+	StatusFailed = "failed"
+)
+
+// ListenersCheck is the name of the check reporting the readiness of the listeners.
+const ListenersCheck = "listeners"
+
+// CheckResult is the result of a single check.
+type CheckResult struct {
+	// Status is the status of the check.
+	Status string `json:"status"`
+	// Error is the error message of a failing check.
+	Error string `json:"error,omitempty"`
+	// Latency is the time it took to evaluate the check.
+	Latency string `json:"latency"`
+}
+
+// Report is the result of evaluating a set of checks.
+type Report struct {
+	// Status is the overall status of the checks.
+	Status string `json:"status"`
+	// Checks are the results of the individual checks.
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Health is a registry of named checks to report the health of the server.
+// The readiness is driven by the server and flips to true once all the
+// waiting listeners are ready and back to false when the shutdown starts.
+type Health struct {
+	mu        sync.RWMutex
+	liveness  map[string]envx.Check
+	readiness map[string]envx.Check
+
+	ready atomic.Bool
+}
+
+// NewHealth returns a new health registry.
+func NewHealth() *Health {
+	return &Health{
+		liveness:  make(map[string]envx.Check),
+		readiness: make(map[string]envx.Check),
+	}
+}
+
+// AddLivenessCheck is adding a named check to the liveness checks.
+func (h *Health) AddLivenessCheck(name string, check envx.Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.liveness[name] = check
+}
+
+// AddReadinessCheck is adding a named check to the readiness checks.
+func (h *Health) AddReadinessCheck(name string, check envx.Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.readiness[name] = check
+}
+
+// Ready returns true if all the waiting listeners are ready.
+func (h *Health) Ready() bool {
+	return h.ready.Load()
+}
+
+func (h *Health) setReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+func (h *Health) listeners(context.Context) error {
+	if !h.Ready() {
+		return ErrNotReady
+	}
+
+	return nil
+}
+
+// Liveness evaluates the liveness checks.
+func (h *Health) Liveness(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := maps.Clone(h.liveness)
+	h.mu.RUnlock()
+
+	return evaluate(ctx, checks)
+}
+
+// Readiness evaluates the readiness checks and the readiness of the listeners.
+func (h *Health) Readiness(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := maps.Clone(h.readiness)
+	h.mu.RUnlock()
+
+	checks[ListenersCheck] = h.listeners
+
+	return evaluate(ctx, checks)
+}
+
+// Healthz evaluates all the checks.
+func (h *Health) Healthz(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := maps.Clone(h.liveness)
+	maps.Copy(checks, h.readiness)
+	h.mu.RUnlock()
+
+	checks[ListenersCheck] = h.listeners
+
+	return evaluate(ctx, checks)
+}
+
+// LivenessHandler returns a handler serving the liveness report.
+func (h *Health) LivenessHandler() http.Handler {
+	return reportHandler(h.Liveness)
+}
+
+// ReadinessHandler returns a handler serving the readiness report.
+func (h *Health) ReadinessHandler() http.Handler {
+	return reportHandler(h.Readiness)
+}
+
+// HealthzHandler returns a handler serving the report of all checks.
+func (h *Health) HealthzHandler() http.Handler {
+	return reportHandler(h.Healthz)
+}
+
+func reportHandler(fn func(context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := fn(r.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+func evaluate(ctx context.Context, checks map[string]envx.Check) Report {
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for name, check := range checks {
+		wg.Go(func() {
+			start := time.Now()
+			err := check(ctx)
+
+			result := CheckResult{Status: StatusOK, Latency: time.Since(start).String()}
+			if err != nil {
+				result.Status = StatusFailed
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = StatusFailed
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return report
+}
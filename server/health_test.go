@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthLiveness(t *testing.T) {
+	h := NewHealth()
+	h.AddLivenessCheck("ok", func(ctx context.Context) error { return nil })
+
+	report := h.Liveness(t.Context())
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, StatusOK, report.Checks["ok"].Status)
+	assert.NotEmpty(t, report.Checks["ok"].Latency)
+
+	h.AddLivenessCheck("failed", func(ctx context.Context) error { return errors.New("failed") })
+
+	report = h.Liveness(t.Context())
+	assert.Equal(t, StatusFailed, report.Status)
+	assert.Equal(t, StatusFailed, report.Checks["failed"].Status)
+	assert.Equal(t, "failed", report.Checks["failed"].Error)
+}
+
+func TestHealthReadiness(t *testing.T) {
+	h := NewHealth()
+	h.AddReadinessCheck("ok", func(ctx context.Context) error { return nil })
+
+	report := h.Readiness(t.Context())
+	assert.Equal(t, StatusFailed, report.Status)
+	assert.Equal(t, ErrNotReady.Error(), report.Checks[ListenersCheck].Error)
+
+	h.setReady(true)
+
+	report = h.Readiness(t.Context())
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Len(t, report.Checks, 2)
+}
+
+func TestHealthHandler(t *testing.T) {
+	h := NewHealth()
+	h.AddLivenessCheck("live", func(ctx context.Context) error { return nil })
+	h.AddReadinessCheck("ready", func(ctx context.Context) error { return nil })
+
+	rec := httptest.NewRecorder()
+	h.HealthzHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	h.setReady(true)
+
+	rec = httptest.NewRecorder()
+	h.HealthzHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var report Report
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Len(t, report.Checks, 3)
+}
+
+type readiness struct {
+	health *Health
+	cancel context.CancelFunc
+}
+
+func (r *readiness) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ready()
+
+		for !r.health.Ready() {
+			time.Sleep(time.Millisecond)
+		}
+
+		r.cancel()
+
+		return nil
+	}
+}
+
+func TestServerReadiness(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx)
+
+	assert.False(t, srv.Health().Ready())
+
+	srv.Listen(&readiness{health: srv.Health(), cancel: cancel}, true)
+
+	require.NoError(t, srv.Wait())
+	assert.False(t, srv.Health().Ready())
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	_ Listener = (*web)(nil)
+	_ Stopper  = (*web)(nil)
+)
+
+const (
+	// DefaultReadHeaderTimeout is the default time to read the request headers.
+	DefaultReadHeaderTimeout = 10 * time.Second
+	// DefaultReadTimeout is the default time to read the request.
+	DefaultReadTimeout = 30 * time.Second
+	// DefaultWriteTimeout is the default time to write the response.
+	DefaultWriteTimeout = 30 * time.Second
+	// DefaultIdleTimeout is the default time to keep idle connections open.
+	DefaultIdleTimeout = 120 * time.Second
+)
+
+// listenFDsStart is the first file descriptor passed by systemd.
+const listenFDsStart = 3
+
+type web struct {
+	opts    *HTTPOpts
+	handler *http.Server
+
+	mu   sync.Mutex
+	addr net.Addr
+}
+
+// HTTPOpts are the options for the HTTP listener.
+type HTTPOpts struct {
+	// Network is the network to listen on, e.g. "tcp" or "unix".
+	Network string
+	// Addr is the address to listen on, or the path of the unix socket.
+	Addr string
+	// File is a pre-opened file descriptor to listen on.
+	File *os.File
+	// ReadHeaderTimeout is the time to read the request headers.
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout is the time to read the request.
+	ReadTimeout time.Duration
+	// WriteTimeout is the time to write the response.
+	WriteTimeout time.Duration
+	// IdleTimeout is the time to keep idle connections open.
+	IdleTimeout time.Duration
+	// Middlewares are wrapping the handler, the first middleware is the outermost.
+	Middlewares []Middleware
+}
+
+// Configure is a method that configures the HTTP options.
+func (o *HTTPOpts) Configure(opts ...HTTPOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultHTTPOpts returns the default options for the HTTP listener.
+func DefaultHTTPOpts() *HTTPOpts {
+	return &HTTPOpts{
+		Network:           "tcp",
+		Addr:              ":8080",
+		ReadHeaderTimeout: DefaultReadHeaderTimeout,
+		ReadTimeout:       DefaultReadTimeout,
+		WriteTimeout:      DefaultWriteTimeout,
+		IdleTimeout:       DefaultIdleTimeout,
+		Middlewares:       []Middleware{RequestID(), AccessLog(), Recover()},
+	}
+}
+
+// HTTPOpt is a function that configures the HTTP options.
+type HTTPOpt func(*HTTPOpts)
+
+// NewHTTP is a function that creates a new HTTP listener serving the handler.
+// By default the requests get a request ID, are logged and recover from panics.
+func NewHTTP(handler http.Handler, opts ...HTTPOpt) *web {
+	options := DefaultHTTPOpts()
+	options.Configure(opts...)
+
+	w := new(web)
+	w.opts = options
+
+	w.handler = new(http.Server)
+	w.handler.Handler = Chain(handler, options.Middlewares...)
+	w.handler.ReadHeaderTimeout = options.ReadHeaderTimeout
+	w.handler.ReadTimeout = options.ReadTimeout
+	w.handler.WriteTimeout = options.WriteTimeout
+	w.handler.IdleTimeout = options.IdleTimeout
+
+	return w
+}
+
+// Start is a method that starts the HTTP listener.
+// The listener is ready once the socket is bound.
+func (w *web) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ln, err := w.listen(ctx)
+		if err != nil {
+			return err
+		}
+
+		w.mu.Lock()
+		w.addr = ln.Addr()
+		w.mu.Unlock()
+
+		ready()
+
+		if err := w.handler.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// Stop is a method that gracefully shuts down the HTTP listener.
+func (w *web) Stop(ctx context.Context) error {
+	return w.handler.Shutdown(ctx)
+}
+
+func (w *web) listen(ctx context.Context) (net.Listener, error) {
+	if w.opts.File != nil {
+		return net.FileListener(w.opts.File)
+	}
+
+	if w.opts.Network == "unix" {
+		if err := removeSocket(w.opts.Addr); err != nil {
+			return nil, err
+		}
+	}
+
+	var lc net.ListenConfig
+
+	return lc.Listen(ctx, w.opts.Network, w.opts.Addr)
+}
+
+// removeSocket is removing a stale unix socket at the path,
+// it fails if there is a file at the path which is not a socket.
+func removeSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return NewServerError(fmt.Errorf("%s exists and is not a unix socket", path))
+	}
+
+	return os.Remove(path)
+}
+
+// WithHTTPAddr is setting the TCP address to listen on.
+func WithHTTPAddr(addr string) HTTPOpt {
+	return func(opts *HTTPOpts) {
+		opts.Network = "tcp"
+		opts.Addr = addr
+	}
+}
+
+// WithHTTPUnixSocket is listening on the unix socket at the path.
+func WithHTTPUnixSocket(path string) HTTPOpt {
+	return func(opts *HTTPOpts) {
+		opts.Network = "unix"
+		opts.Addr = path
+	}
+}
+
+// WithHTTPFile is listening on the pre-opened file descriptor,
+// e.g. one of the files returned by ListenFDs.
+func WithHTTPFile(f *os.File) HTTPOpt {
+	return func(opts *HTTPOpts) {
+		opts.File = f
+	}
+}
+
+// WithHTTPTimeouts is setting the read, write and idle timeouts.
+func WithHTTPTimeouts(read, write, idle time.Duration) HTTPOpt {
+	return func(opts *HTTPOpts) {
+		opts.ReadTimeout = read
+		opts.WriteTimeout = write
+		opts.IdleTimeout = idle
+	}
+}
+
+// WithHTTPReadHeaderTimeout is setting the time to read the request headers.
+func WithHTTPReadHeaderTimeout(timeout time.Duration) HTTPOpt {
+	return func(opts *HTTPOpts) {
+		opts.ReadHeaderTimeout = timeout
+	}
+}
+
+// WithHTTPMiddleware is adding middlewares inside the default middlewares.
+func WithHTTPMiddleware(middlewares ...Middleware) HTTPOpt {
+	return func(opts *HTTPOpts) {
+		opts.Middlewares = append(opts.Middlewares, middlewares...)
+	}
+}
+
+// WithHTTPMiddlewares is replacing all the middlewares, including the default middlewares.
+func WithHTTPMiddlewares(middlewares ...Middleware) HTTPOpt {
+	return func(opts *HTTPOpts) {
+		opts.Middlewares = middlewares
+	}
+}
+
+// ListenFDs returns the file descriptors passed by systemd socket activation.
+func ListenFDs() ([]*os.File, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil //nolint:nilerr
+	}
+
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil {
+		return nil, NewServerError(fmt.Errorf("invalid LISTEN_FDS: %w", err))
+	}
+
+	files := make([]*os.File, 0, n)
+	for fd := listenFDsStart; fd < listenFDsStart+n; fd++ {
+		files = append(files, os.NewFile(uintptr(fd), "LISTEN_FD_"+strconv.Itoa(fd)))
+	}
+
+	return files, nil
+}
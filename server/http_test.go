@@ -0,0 +1,237 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	order := []string{}
+
+	mw := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}), mw("first"), mw("second"))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{"first", "second", "handler"}, order)
+}
+
+func TestRequestID(t *testing.T) {
+	var id string
+
+	h := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = RequestIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.NotEmpty(t, id)
+	assert.Equal(t, id, rec.Header().Get(RequestIDHeader))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "foo")
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, "foo", id)
+	assert.Equal(t, "foo", rec.Header().Get(RequestIDHeader))
+
+	for _, invalid := range []string{"foo\r\nbar", "foo bar", strings.Repeat("a", MaxRequestIDLength+1)} {
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, invalid)
+
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.NotEqual(t, invalid, id)
+		assert.NotEmpty(t, id)
+		assert.Equal(t, id, rec.Header().Get(RequestIDHeader))
+	}
+}
+
+func TestRecover(t *testing.T) {
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), RequestID(), AccessLog(), Recover())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestStatusWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sw := &statusWriter{ResponseWriter: rec, status: http.StatusOK}
+
+	sw.WriteHeader(http.StatusTeapot)
+	_, err := sw.Write([]byte("tea"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusTeapot, sw.status)
+	assert.Equal(t, 3, sw.bytes)
+	assert.Equal(t, rec, sw.Unwrap())
+}
+
+func TestAccessLogStreaming(t *testing.T) {
+	read := make(chan struct{})
+
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(http.Hijacker)
+		assert.True(t, ok)
+
+		f, ok := w.(http.Flusher)
+		if !assert.True(t, ok) {
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		_, _ = io.WriteString(w, "data: 1\n\n")
+		f.Flush()
+
+		// the client must receive the first event before the handler returns.
+		select {
+		case <-read:
+		case <-r.Context().Done():
+			return
+		}
+
+		_, _ = io.WriteString(w, "data: 2\n\n")
+	}), DefaultHTTPOpts().Middlewares...)
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	r := bufio.NewReader(resp.Body)
+
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "data: 1\n", line)
+	close(read)
+
+	body, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "\ndata: 2\n\n", string(body))
+}
+
+func hello() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+}
+
+func serveHTTP(t *testing.T, w *web, client *http.Client, url string) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx, WithShutdownTimeout(time.Second))
+	srv.Listen(w, true)
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Wait() }()
+
+	require.Eventually(t, srv.Health().Ready, time.Second, time.Millisecond)
+
+	if url == "" {
+		w.mu.Lock()
+		url = "http://" + w.addr.String()
+		w.mu.Unlock()
+	}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hello", string(body))
+	assert.NotEmpty(t, resp.Header.Get(RequestIDHeader))
+
+	cancel()
+	require.NoError(t, <-errs)
+}
+
+func TestHTTPListener(t *testing.T) {
+	serveHTTP(t, NewHTTP(hello(), WithHTTPAddr("127.0.0.1:0")), http.DefaultClient, "")
+}
+
+func TestHTTPListenerUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.sock")
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}
+
+	serveHTTP(t, NewHTTP(hello(), WithHTTPUnixSocket(path)), client, "http://unix/")
+}
+
+func TestRemoveSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.sock")
+	require.NoError(t, removeSocket(path))
+
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+	require.Error(t, removeSocket(path))
+	assert.FileExists(t, path)
+}
+
+func TestHTTPListenerFile(t *testing.T) {
+	var lc net.ListenConfig
+
+	ln, err := lc.Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	f, err := ln.(*net.TCPListener).File()
+	require.NoError(t, err)
+	defer f.Close()
+
+	serveHTTP(t, NewHTTP(hello(), WithHTTPFile(f)), http.DefaultClient, "http://"+ln.Addr().String())
+}
+
+func TestListenFDs(t *testing.T) {
+	t.Setenv("LISTEN_PID", "")
+
+	files, err := ListenFDs()
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	rdebug "runtime/debug"
+	"time"
+
+	pkgctx "github.com/katallaxie/pkg/ctx"
+	"github.com/katallaxie/pkg/logx"
+	"github.com/katallaxie/pkg/ulid"
+)
+
+const (
+	// RequestIDHeader is the header to carry the request ID.
+	RequestIDHeader = pkgctx.RequestIDHeader
+	// MaxRequestIDLength is the maximum length of an accepted request ID.
+	MaxRequestIDLength = pkgctx.MaxRequestIDLength
+)
+
+// Middleware is a function wrapping a http.Handler.
+type Middleware func(http.Handler) http.Handler
+
+// Chain is wrapping the handler with the middlewares,
+// the first middleware is the outermost.
+func Chain(handler http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// RequestIDFromContext returns the request ID from the context,
+// it is stored with the ctx.RequestID key.
+func RequestIDFromContext(ctx context.Context) string {
+	return pkgctx.RequestID.Value(ctx)
+}
+
+// RequestID is a middleware injecting a request ID into the context and the response.
+// The request ID of the request header is used if it is valid, otherwise a new one is generated.
+// It is stored with the ctx.RequestID key, so it is added to the fields of the logx Ctx functions.
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+
+			if !pkgctx.ValidRequestID(id) {
+				if u, err := ulid.New(); err == nil {
+					id = u.String()
+				}
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+
+			next.ServeHTTP(w, r.WithContext(pkgctx.RequestID.WithValue(r.Context(), id)))
+		})
+	}
+}
+
+// Recover is a middleware recovering from panics of the handler
+// and responding with an internal server error.
+func Recover() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				logx.ErrorCtx(r.Context(), "http handler panic",
+					"error", fmt.Sprint(rec),
+					"stack", string(rdebug.Stack()),
+				)
+
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AccessLog is a middleware logging the requests with logx,
+// including the fields of the context of the request.
+func AccessLog() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			logx.InfoCtx(r.Context(), "http request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"remote", r.RemoteAddr,
+				"status", sw.status,
+				"bytes", sw.bytes,
+				"duration", time.Since(start),
+			)
+		})
+	}
+}
+
+var (
+	_ http.Flusher  = (*statusWriter)(nil)
+	_ http.Hijacker = (*statusWriter)(nil)
+)
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+	wrote  bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wrote {
+		w.status = status
+		w.wrote = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+
+	return n, err
+}
+
+// Flush is flushing the underlying response writer, e.g. for streaming responses.
+func (w *statusWriter) Flush() {
+	w.wrote = true
+
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack is hijacking the connection of the underlying response writer, e.g. for websockets.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying response writer for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
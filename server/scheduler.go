@@ -0,0 +1,254 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/pkg/logx"
+)
+
+var (
+	_ Listener     = (*scheduler)(nil)
+	_ http.Handler = (*scheduler)(nil)
+)
+
+// ErrDuplicateJob is returned when a job with the same name is already scheduled.
+var ErrDuplicateJob = errors.New("duplicate job")
+
+// JobFunc is the function run by a scheduled job.
+type JobFunc func(ctx context.Context) error
+
+// JobOpts are the options for a scheduled job.
+type JobOpts struct {
+	// Jitter is the maximum random delay added to each run.
+	Jitter time.Duration
+}
+
+// JobOpt is a function that configures the job options.
+type JobOpt func(*JobOpts)
+
+// WithJitter is adding a random delay up to the jitter to each run of the job.
+func WithJitter(jitter time.Duration) JobOpt {
+	return func(opts *JobOpts) {
+		opts.Jitter = jitter
+	}
+}
+
+// JobState is the state of a scheduled job.
+type JobState struct {
+	// Name is the name of the job.
+	Name string `json:"name"`
+	// Schedule is the schedule of the job.
+	Schedule string `json:"schedule"`
+	// Running is true while the job is running.
+	Running bool `json:"running"`
+	// Runs is the number of runs of the job.
+	Runs int `json:"runs"`
+	// Failures is the number of failed runs of the job.
+	Failures int `json:"failures"`
+	// LastRun is the start time of the last run.
+	LastRun time.Time `json:"last_run,omitzero"`
+	// LastDuration is the duration of the last run.
+	LastDuration string `json:"last_duration,omitempty"`
+	// LastError is the error of the last run.
+	LastError string `json:"last_error,omitempty"`
+	// NextRun is the time of the next run.
+	NextRun time.Time `json:"next_run,omitzero"`
+}
+
+type scheduledJob struct {
+	name     string
+	schedule Schedule
+	fn       JobFunc
+	opts     *JobOpts
+
+	mu    sync.Mutex
+	state JobState
+}
+
+func (j *scheduledJob) snapshot() JobState {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.state
+}
+
+func (j *scheduledJob) update(fn func(*JobState)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	fn(&j.state)
+}
+
+type scheduler struct {
+	mu   sync.Mutex
+	jobs []*scheduledJob
+}
+
+// NewScheduler is a function that creates a new scheduler listener.
+// The jobs are running until the context of the listener is canceled,
+// a job is never running concurrently with itself.
+func NewScheduler() *scheduler {
+	return new(scheduler)
+}
+
+// Add is scheduling a job with the schedule.
+func (s *scheduler) Add(name string, schedule Schedule, fn JobFunc, opts ...JobOpt) error {
+	options := new(JobOpts)
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if e, ok := schedule.(every); ok && e.interval <= 0 {
+		return NewServerError(fmt.Errorf("%w: %s: %s", ErrInvalidInterval, name, e.interval))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.jobs, func(j *scheduledJob) bool { return j.name == name }) {
+		return NewServerError(fmt.Errorf("%w: %s", ErrDuplicateJob, name))
+	}
+
+	s.jobs = append(s.jobs, &scheduledJob{
+		name:     name,
+		schedule: schedule,
+		fn:       fn,
+		opts:     options,
+		state:    JobState{Name: name, Schedule: fmt.Sprint(schedule)},
+	})
+
+	return nil
+}
+
+// Cron is scheduling a job with the cron expression.
+func (s *scheduler) Cron(name, expr string, fn JobFunc, opts ...JobOpt) error {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return err
+	}
+
+	return s.Add(name, schedule, fn, opts...)
+}
+
+// Every is scheduling a job at a fixed interval.
+func (s *scheduler) Every(name string, interval time.Duration, fn JobFunc, opts ...JobOpt) error {
+	return s.Add(name, Every(interval), fn, opts...)
+}
+
+// Jobs returns the state of the jobs.
+func (s *scheduler) Jobs() []JobState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]JobState, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		states = append(states, j.snapshot())
+	}
+
+	return states
+}
+
+// Start is a method that starts the scheduler listener.
+func (s *scheduler) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		s.mu.Lock()
+		jobs := slices.Clone(s.jobs)
+		s.mu.Unlock()
+
+		for _, j := range jobs {
+			run(func() error {
+				s.loop(ctx, j)
+				return nil
+			})
+		}
+
+		ready()
+
+		<-ctx.Done()
+
+		return nil
+	}
+}
+
+// ServeHTTP is serving the state of the jobs.
+func (s *scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(s.Jobs())
+}
+
+func (s *scheduler) loop(ctx context.Context, j *scheduledJob) {
+	for {
+		next := j.schedule.Next(time.Now())
+		if next.IsZero() {
+			j.update(func(state *JobState) { state.NextRun = time.Time{} })
+			return
+		}
+
+		if j.opts.Jitter > 0 {
+			next = next.Add(rand.N(j.opts.Jitter)) //nolint:gosec
+		}
+
+		j.update(func(state *JobState) { state.NextRun = next })
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.exec(ctx, j)
+	}
+}
+
+func (s *scheduler) exec(ctx context.Context, j *scheduledJob) {
+	start := time.Now()
+
+	j.update(func(state *JobState) {
+		state.Running = true
+		state.LastRun = start
+	})
+
+	err := j.fn(ctx)
+
+	j.update(func(state *JobState) {
+		state.Running = false
+		state.Runs++
+		state.LastDuration = time.Since(start).String()
+		state.LastError = ""
+
+		if err != nil {
+			state.Failures++
+			state.LastError = err.Error()
+		}
+	})
+
+	if err != nil && ctx.Err() == nil {
+		logx.Errorw("scheduled job failed", "job", j.name, "error", err)
+	}
+}
+
+// String returns the names of the jobs.
+func (s *scheduler) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		names = append(names, j.name)
+	}
+
+	return "scheduler(" + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerAdd(t *testing.T) {
+	s := NewScheduler()
+
+	require.NoError(t, s.Every("every", time.Minute, func(ctx context.Context) error { return nil }))
+	require.NoError(t, s.Cron("cron", "*/5 * * * *", func(ctx context.Context) error { return nil }))
+	require.ErrorIs(t, s.Every("every", time.Minute, func(ctx context.Context) error { return nil }), ErrDuplicateJob)
+	require.ErrorIs(t, s.Cron("invalid", "* *", func(ctx context.Context) error { return nil }), ErrInvalidCron)
+	require.ErrorIs(t, s.Every("zero", 0, func(ctx context.Context) error { return nil }), ErrInvalidInterval)
+	require.ErrorIs(t, s.Add("negative", Every(-time.Second), func(ctx context.Context) error { return nil }), ErrInvalidInterval)
+
+	jobs := s.Jobs()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "every", jobs[0].Name)
+	assert.Equal(t, "@every 1m0s", jobs[0].Schedule)
+	assert.Equal(t, "cron", jobs[1].Name)
+	assert.Equal(t, "*/5 * * * *", jobs[1].Schedule)
+	assert.Equal(t, "scheduler(every, cron)", s.String())
+}
+
+func TestSchedulerRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx, WithShutdownTimeout(time.Second))
+
+	var (
+		runs       atomic.Int32
+		running    atomic.Int32
+		overlapped atomic.Bool
+	)
+
+	s := NewScheduler()
+	require.NoError(t, s.Every("slow", time.Millisecond, func(ctx context.Context) error {
+		if running.Add(1) > 1 {
+			overlapped.Store(true)
+		}
+		defer running.Add(-1)
+
+		runs.Add(1)
+		time.Sleep(5 * time.Millisecond)
+
+		return errors.New("failed")
+	}, WithJitter(time.Millisecond)))
+
+	srv.Listen(s, true)
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Wait() }()
+
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-errs)
+
+	assert.False(t, overlapped.Load())
+
+	state := s.Jobs()[0]
+	assert.GreaterOrEqual(t, state.Runs, 3)
+	assert.Equal(t, state.Runs, state.Failures)
+	assert.Equal(t, "failed", state.LastError)
+	assert.False(t, state.LastRun.IsZero())
+	assert.NotEmpty(t, state.LastDuration)
+}
+
+func TestSchedulerServeHTTP(t *testing.T) {
+	s := NewScheduler()
+	require.NoError(t, s.Every("job", time.Minute, func(ctx context.Context) error { return nil }))
+
+	rec := httptest.NewRecorder()
+	NewDebug(WithRoute("/debug/jobs", s)).mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/jobs", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var jobs []JobState
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "job", jobs[0].Name)
+}
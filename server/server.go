@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -14,6 +16,16 @@ import (
 // ErrUnimplemented is returned when a listener is not implemented.
 var ErrUnimplemented = errors.New("unimplemented")
 
+// ErrForcedShutdown is returned when a shutdown signal is received while draining.
+var ErrForcedShutdown = errors.New("forced shutdown")
+
+// ErrShutdownTimeout is returned when listeners are still running
+// after the shutdown timeout has expired.
+var ErrShutdownTimeout = errors.New("shutdown timeout")
+
+// DefaultShutdownTimeout is the default time to wait for listeners to drain.
+const DefaultShutdownTimeout = 30 * time.Second
+
 type token struct{}
 
 // ReadyFunc is the function that is called by Listener to signal
@@ -59,6 +71,20 @@ type Server interface {
 	SetLimit(n int)
 }
 
+// OptsServer is implemented by servers adding listeners with options,
+// e.g. a name, dependencies or the supervision of the listener.
+type OptsServer interface {
+	// ListenWith is adding a listener with options
+	ListenWith(listener Listener, opts ...ListenOpt)
+}
+
+// HealthReporter is implemented by servers reporting their health,
+// e.g. to serve it on the debug listener.
+type HealthReporter interface {
+	// Health returns the health registry of the server.
+	Health() *Health
+}
+
 // Unimplemented is the default implementation.
 type Unimplemented struct{}
 
@@ -77,13 +103,168 @@ type Listener interface {
 	Start(context.Context, ReadyFunc, RunFunc) func() error
 }
 
-var _ Server = (*server)(nil)
+// Stopper is an optional interface for a listener
+// to be gracefully stopped when the server shuts down.
+type Stopper interface {
+	// Stop is called in reverse start order when the server shuts down.
+	// The context expires when the shutdown timeout is reached.
+	Stop(context.Context) error
+}
+
+// Reloader is an optional interface for a listener
+// to reload its configuration when a reload signal is received.
+type Reloader interface {
+	// Reload is called in start order when a reload signal is received.
+	Reload(context.Context) error
+}
+
+// DefaultSignals are the default signals to shutdown the server.
+var DefaultSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT}
+
+// DefaultReloadSignals are the common signals to reload the listeners,
+// reloading is enabled with WithReloadSignals(DefaultReloadSignals...).
+var DefaultReloadSignals = []os.Signal{syscall.SIGHUP}
+
+// Opts are the options for the server.
+type Opts struct {
+	// ShutdownTimeout is the time to wait for the listeners to drain.
+	ShutdownTimeout time.Duration
+	// Hooks are called on the lifecycle events of the listeners.
+	Hooks []Hooks
+	// Signals are the signals to shutdown the server,
+	// receiving one of them again while draining forces the shutdown.
+	Signals []os.Signal
+	// ReloadSignals are the signals to reload the listeners, none by default.
+	ReloadSignals []os.Signal
+}
+
+// Configure is a method that configures the server options.
+func (o *Opts) Configure(opts ...Opt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// Opt is a function that configures the server options.
+type Opt func(*Opts)
+
+// WithShutdownTimeout is setting the time to wait for the listeners to drain.
+func WithShutdownTimeout(timeout time.Duration) Opt {
+	return func(opts *Opts) {
+		opts.ShutdownTimeout = timeout
+	}
+}
+
+// ListenOpts are the options for a listener.
+type ListenOpts struct {
+	// Name is the name of the listener.
+	Name string
+	// Wait is blocking the start of the next listeners until the listener is ready.
+	Wait bool
+	// Supervision configures the restart of the listener.
+	Supervision Supervision
+	// DependsOn are the names of the listeners to be ready before the listener is started.
+	DependsOn []string
+}
+
+// Configure is a method that configures the listener options.
+func (o *ListenOpts) Configure(opts ...ListenOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// ListenOpt is a function that configures the listener options.
+type ListenOpt func(*ListenOpts)
+
+// WithName is setting the name of the listener.
+func WithName(name string) ListenOpt {
+	return func(opts *ListenOpts) {
+		opts.Name = name
+	}
+}
+
+// WithWait is blocking the start of the next listeners until the listener is ready.
+func WithWait() ListenOpt {
+	return func(opts *ListenOpts) {
+		opts.Wait = true
+	}
+}
+
+// WithDependsOn is starting the listener after the named listeners are ready.
+// A listener that returns without calling the ReadyFunc is ready when it returned without an error.
+func WithDependsOn(names ...string) ListenOpt {
+	return func(opts *ListenOpts) {
+		opts.DependsOn = append(opts.DependsOn, names...)
+	}
+}
+
+// WithRestart is supervising the listener with the default supervision of the policy.
+func WithRestart(policy RestartPolicy) ListenOpt {
+	return func(opts *ListenOpts) {
+		opts.Supervision = DefaultSupervision(policy)
+	}
+}
+
+// WithSupervision is supervising the listener,
+// the unset fields are set to the default supervision of the policy.
+func WithSupervision(sup Supervision) ListenOpt {
+	return func(opts *ListenOpts) {
+		opts.Supervision = sup.withDefaults()
+	}
+}
+
+// WithSignals is setting the signals to shutdown the server.
+func WithSignals(signals ...os.Signal) Opt {
+	return func(opts *Opts) {
+		opts.Signals = signals
+	}
+}
+
+// WithReloadSignals is setting the signals to reload the listeners.
+func WithReloadSignals(signals ...os.Signal) Opt {
+	return func(opts *Opts) {
+		opts.ReloadSignals = signals
+	}
+}
+
+// WithHooks is adding hooks for the lifecycle events of the listeners.
+func WithHooks(hooks ...Hooks) Opt {
+	return func(opts *Opts) {
+		opts.Hooks = append(opts.Hooks, hooks...)
+	}
+}
+
+// WithoutHooks is removing all hooks.
+func WithoutHooks() Opt {
+	return func(opts *Opts) {
+		opts.Hooks = nil
+	}
+}
+
+var (
+	_ Server         = (*server)(nil)
+	_ OptsServer     = (*server)(nil)
+	_ HealthReporter = (*server)(nil)
+)
+
+type listener struct {
+	Listener
+
+	name    string
+	opts    *ListenOpts
+	deps    listeners
+	ready   chan struct{}
+	once    sync.Once
+	history history
+}
 
-type listeners map[Listener]bool
+type listeners []*listener
 
 type server struct {
 	ctx    context.Context
 	cancel context.CancelFunc
+	opts   *Opts
 
 	wg      sync.WaitGroup
 	errOnce sync.Once
@@ -91,108 +272,323 @@ type server struct {
 
 	sem chan token
 
-	listeners map[Listener]bool
+	health *Health
+
+	listeners listeners
+	started   listeners
+
+	mu      sync.Mutex
+	running map[string]int
 
-	ready chan bool
-	sys   chan os.Signal
+	sys chan os.Signal
 }
 
 // WithContext is creating a new server with a context.
-func WithContext(ctx context.Context) (*server, context.Context) {
+func WithContext(ctx context.Context, opts ...Opt) (*server, context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 
 	// new server
-	s := newServer(ctx)
+	s := newServer(ctx, opts...)
 	s.cancel = cancel
 	s.ctx = ctx
 
 	return s, ctx
 }
 
-func newServer(ctx context.Context) *server {
+func newServer(ctx context.Context, opts ...Opt) *server {
 	s := new(server)
 
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
 	s.ctx = ctx
 
-	s.listeners = make(listeners)
-	s.ready = make(chan bool, 1)
+	s.opts = &Opts{
+		ShutdownTimeout: DefaultShutdownTimeout,
+		Signals:         DefaultSignals,
+	}
+	s.opts.Configure(opts...)
+
+	s.health = NewHealth()
+	s.listeners = make(listeners, 0)
+	s.started = make(listeners, 0)
+	s.running = make(map[string]int)
 	s.sys = make(chan os.Signal, 1)
 
 	return s
 }
 
 // Listen is adding a listener to the server.
-func (s *server) Listen(listener Listener, wait ...bool) {
-	waiting := false
+func (s *server) Listen(l Listener, wait ...bool) {
+	opts := []ListenOpt{}
 
-	if len(wait) > 0 {
-		waiting = wait[0]
+	if len(wait) > 0 && wait[0] {
+		opts = append(opts, WithWait())
 	}
 
-	s.listeners[listener] = waiting
+	s.ListenWith(l, opts...)
+}
+
+// ListenWith is adding a listener with options to the server.
+func (s *server) ListenWith(l Listener, opts ...ListenOpt) {
+	options := &ListenOpts{Name: fmt.Sprintf("%T", l)}
+	options.Configure(opts...)
+
+	s.listeners = append(s.listeners, &listener{
+		Listener: l,
+		name:     options.Name,
+		opts:     options,
+		ready:    make(chan struct{}),
+	})
 }
 
 // Wait is waiting for the server to shutdown or fail.
-// The returned error is the first error that occurred from the listeners.
-//
-//nolint:gocyclo
+// The listeners are started in the order of their dependencies,
+// independent listeners are started in parallel.
+// When the context is canceled or a shutdown signal is received,
+// the listeners implementing Stopper are stopped in reverse start order
+// and Wait blocks until all routines have finished or the shutdown timeout expired.
+// Receiving a second shutdown signal while draining returns immediately.
+// The listeners implementing Reloader are reloaded when a reload signal is received.
+// The returned error is the first error that occurred from the listeners,
+// joined with the errors that occurred during the shutdown.
 func (s *server) Wait() error {
-	// create ticker for interrupt signals
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	signal.Notify(s.sys, syscall.SIGTERM, syscall.SIGINT)
-	defer signal.Reset(syscall.SIGINT, syscall.SIGTERM)
-
-OUTTER:
-	for l, ready := range s.listeners {
-		readyFunc := func() {
-			r := ready
-
-			var readyOnce sync.Once
-			readyOnce.Do(func() {
-				if r {
-					s.ready <- true
-				}
-			})
-		}
+	if signals := slices.Concat(s.opts.Signals, s.opts.ReloadSignals); len(signals) > 0 {
+		signal.Notify(s.sys, signals...)
+		defer signal.Stop(s.sys)
+	}
 
-		goFn := func(f func() error) { s.run(f) }
-
-		// schedule to routines
-		s.run(l.Start(s.ctx, readyFunc, goFn))
-
-		// this blocks until ready is called
-		if ready {
-			select {
-			case <-s.ready:
-				continue OUTTER
-			case <-s.sys:
-				s.cancel()
-				break OUTTER
-			case <-s.ctx.Done():
-				break OUTTER
-			}
-		}
+	started := s.start()
+	pending := len(s.listeners)
+	signaled := false
+
+	if pending == 0 {
+		s.health.setReady(true)
 	}
 
 	for {
 		select {
-		case <-ticker.C:
-		case <-s.sys:
+		case <-started:
+			if pending--; pending == 0 {
+				s.health.setReady(true)
+			}
+		case sig := <-s.sys:
+			if slices.Contains(s.opts.ReloadSignals, sig) {
+				s.reload()
+				continue
+			}
+
 			// if there is sys interrupt
 			// cancel the context of the routines
+			signaled = true
 			s.cancel()
 		case <-s.ctx.Done():
-			if s.err != nil {
-				return s.err
+			return s.drain(signaled)
+		}
+	}
+}
+
+// drain is shutting down the server, the second shutdown signal
+// is forcing it to return immediately. The drain counts as the first signal
+// if it was started by one.
+func (s *server) drain(signaled bool) error {
+	done := make(chan error, 1)
+	go func() { done <- s.shutdown() }()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case sig := <-s.sys:
+			if slices.Contains(s.opts.ReloadSignals, sig) {
+				continue
+			}
+
+			if signaled {
+				return NewServerError(ErrForcedShutdown)
 			}
 
-			return nil
+			signaled = true
+		}
+	}
+}
+
+// reload is reloading the started listeners implementing Reloader.
+func (s *server) reload() {
+	s.mu.Lock()
+	started := slices.Clone(s.started)
+	s.mu.Unlock()
+
+	for _, l := range started {
+		reloader, ok := l.Listener.(Reloader)
+		if !ok {
+			continue
+		}
+
+		err := reloader.Reload(s.ctx)
+		if err != nil {
+			err = NewServerError(fmt.Errorf("reload %s: %w", l.name, err))
+		}
+
+		s.emit(EventReload, l, err)
+	}
+}
+
+// start is starting each listener once its dependencies are ready.
+// The returned channel receives for each listener that is started,
+// and is ready if other listeners are waiting for it.
+func (s *server) start() <-chan struct{} {
+	started := make(chan struct{}, len(s.listeners))
+
+	sorted, err := s.listeners.sort()
+	if err != nil {
+		s.fail(err)
+		return started
+	}
+
+	for _, l := range sorted {
+		s.wg.Add(1)
+
+		go func() {
+			defer s.wg.Done()
+
+			if !s.await(l.deps...) {
+				return
+			}
+
+			s.mu.Lock()
+			if s.ctx.Err() != nil {
+				s.mu.Unlock()
+				return
+			}
+			s.started = append(s.started, l)
+			s.mu.Unlock()
+
+			// schedule to routines
+			s.run(l.name, func() error { return s.supervise(l) })
+
+			// this blocks until ready is called
+			if l.opts.Wait && !s.await(l) {
+				return
+			}
+
+			started <- struct{}{}
+		}()
+	}
+
+	return started
+}
+
+// await is waiting for the listeners to be ready,
+// it returns false if the server is shutting down.
+func (s *server) await(ls ...*listener) bool {
+	for _, l := range ls {
+		select {
+		case <-l.ready:
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// signal is marking the listener as ready.
+func (s *server) signal(l *listener) {
+	l.once.Do(func() {
+		s.emit(EventReady, l, nil)
+		close(l.ready)
+	})
+}
+
+// Health returns the health registry of the server.
+func (s *server) Health() *Health {
+	return s.health
+}
+
+func (s *server) shutdown() error {
+	s.health.setReady(false)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(s.ctx), s.opts.ShutdownTimeout)
+	defer cancel()
+
+	errs := make([]error, 0)
+
+	s.mu.Lock()
+	started := slices.Clone(s.started)
+	s.mu.Unlock()
+
+	for _, l := range slices.Backward(started) {
+		stopper, ok := l.Listener.(Stopper)
+		if !ok {
+			s.emit(EventStop, l, nil)
+			continue
 		}
+
+		err := stopper.Stop(ctx)
+		if err != nil {
+			err = NewServerError(fmt.Errorf("stop %s: %w", l.name, err))
+			errs = append(errs, err)
+		}
+
+		s.emit(EventStop, l, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, NewServerError(fmt.Errorf("%w: still running %s", ErrShutdownTimeout, s.stragglers())))
+	}
+
+	s.errOnce.Do(func() {
+		// noop, required to synchronise on the errOnce mutex.
+	})
+
+	if s.err == nil && len(errs) == 0 {
+		return nil
+	}
+
+	for _, err := range s.Restarts() {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return s.err
 	}
+
+	return errors.Join(append([]error{s.err}, errs...)...)
+}
+
+// Restarts returns the restart history of the listeners which were restarted,
+// it is joined to the error returned by Wait if the server failed.
+func (s *server) Restarts() []*RestartError {
+	errs := make([]*RestartError, 0)
+
+	for _, l := range s.listeners {
+		if restarts := l.history.list(); len(restarts) > 0 {
+			errs = append(errs, &RestartError{Listener: l.name, Restarts: restarts})
+		}
+	}
+
+	return errs
+}
+
+func (s *server) stragglers() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.running))
+	for name, n := range s.running {
+		names = append(names, fmt.Sprintf("%s (%d)", name, n))
+	}
+	slices.Sort(names)
+
+	return strings.Join(names, ", ")
 }
 
 // SetLimit limits the number of active listeners in this server.
@@ -209,33 +605,47 @@ func (s *server) SetLimit(n int) {
 	s.sem = make(chan token, n)
 }
 
-func (s *server) run(f func() error) {
+func (s *server) run(name string, f func() error) {
 	if s.sem != nil {
 		s.sem <- token{}
 	}
 
 	s.wg.Add(1)
 
+	s.mu.Lock()
+	s.running[name]++
+	s.mu.Unlock()
+
 	runFunc := func() {
-		defer s.done()
+		defer s.done(name)
 
 		if err := f(); err != nil {
-			s.errOnce.Do(func() {
-				s.err = err
-				if s.cancel != nil {
-					s.cancel()
-				}
-			})
+			s.fail(err)
 		}
 	}
 
 	go runFunc()
 }
 
-func (s *server) done() {
+func (s *server) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+		if s.cancel != nil {
+			s.cancel()
+		}
+	})
+}
+
+func (s *server) done(name string) {
 	if s.sem != nil {
 		<-s.sem
 	}
 
+	s.mu.Lock()
+	if s.running[name]--; s.running[name] == 0 {
+		delete(s.running, name)
+	}
+	s.mu.Unlock()
+
 	s.wg.Done()
 }
@@ -1,7 +1,10 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -69,3 +72,88 @@ func TestNewError(t *testing.T) {
 	require.NotNil(t, err.Unwrap())
 	require.Equal(t, ErrUnimplemented, err.Unwrap())
 }
+
+type stopper struct {
+	name    string
+	stopped *[]string
+	done    chan struct{}
+	err     error
+}
+
+func (s *stopper) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ready()
+		<-s.done
+
+		return nil
+	}
+}
+
+func (s *stopper) Stop(ctx context.Context) error {
+	*s.stopped = append(*s.stopped, s.name)
+	close(s.done)
+
+	return s.err
+}
+
+type canceler struct {
+	cancel context.CancelFunc
+}
+
+func (c *canceler) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ready()
+		c.cancel()
+
+		return nil
+	}
+}
+
+type blocking struct{}
+
+func (b *blocking) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ready()
+		select {}
+	}
+}
+
+func TestWaitStopsInReverseOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx)
+
+	stopped := []string{}
+	srv.Listen(&stopper{name: "first", stopped: &stopped, done: make(chan struct{})}, true)
+	srv.Listen(&stopper{name: "second", stopped: &stopped, done: make(chan struct{})}, true)
+	srv.Listen(&canceler{cancel: cancel}, true)
+
+	require.NoError(t, srv.Wait())
+	assert.Equal(t, []string{"second", "first"}, stopped)
+}
+
+func TestWaitStopError(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx)
+
+	stopped := []string{}
+	errStop := errors.New("stop")
+	srv.Listen(&stopper{name: "first", stopped: &stopped, done: make(chan struct{}), err: errStop}, true)
+	srv.Listen(&canceler{cancel: cancel}, true)
+
+	err := srv.Wait()
+	require.Error(t, err)
+	require.ErrorIs(t, err, errStop)
+}
+
+func TestWaitShutdownTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx, WithShutdownTimeout(10*time.Millisecond))
+
+	srv.Listen(&blocking{}, true)
+	srv.Listen(&canceler{cancel: cancel}, true)
+
+	err := srv.Wait()
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.Contains(t, err.Error(), "*server.blocking (1)")
+}
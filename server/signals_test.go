@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signaler struct {
+	sys      chan os.Signal
+	sig      os.Signal
+	reloaded chan struct{}
+	release  chan struct{}
+}
+
+func (s *signaler) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ready()
+		s.sys <- s.sig
+		<-ctx.Done()
+
+		return nil
+	}
+}
+
+func (s *signaler) Reload(ctx context.Context) error {
+	close(s.reloaded)
+	s.sys <- syscall.SIGTERM
+
+	return nil
+}
+
+func (s *signaler) Stop(ctx context.Context) error {
+	if s.release == nil {
+		return nil
+	}
+
+	s.sys <- syscall.SIGINT
+	<-s.release
+
+	return nil
+}
+
+func TestSignalShutdown(t *testing.T) {
+	srv, _ := WithContext(t.Context())
+
+	srv.Listen(&signaler{sys: srv.sys, sig: syscall.SIGTERM}, true)
+
+	require.NoError(t, srv.Wait())
+}
+
+func TestSignalReload(t *testing.T) {
+	r := &recorder{}
+	srv, _ := WithContext(t.Context(), WithReloadSignals(DefaultReloadSignals...), WithHooks(Hooks{OnReload: func(e Event) { r.record(e.Listener) }}))
+
+	l := &signaler{sys: srv.sys, sig: syscall.SIGHUP, reloaded: make(chan struct{})}
+	srv.ListenWith(l, WithName("signaler"))
+
+	require.NoError(t, srv.Wait())
+	assert.Equal(t, []string{"signaler"}, r.events)
+
+	select {
+	case <-l.reloaded:
+	default:
+		t.Fatal("listener not reloaded")
+	}
+}
+
+func TestSignalForcedShutdown(t *testing.T) {
+	srv, _ := WithContext(t.Context(), WithShutdownTimeout(time.Minute))
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	srv.Listen(&signaler{sys: srv.sys, sig: syscall.SIGTERM, release: release}, true)
+
+	err := srv.Wait()
+	require.ErrorIs(t, err, ErrForcedShutdown)
+}
+
+type interrupter struct {
+	sys    chan os.Signal
+	cancel context.CancelFunc
+}
+
+func (i *interrupter) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ready()
+		i.cancel()
+		<-ctx.Done()
+
+		return nil
+	}
+}
+
+func (i *interrupter) Stop(ctx context.Context) error {
+	i.sys <- syscall.SIGINT
+	time.Sleep(10 * time.Millisecond)
+
+	return nil
+}
+
+func TestSignalDuringCanceledDrain(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx)
+
+	srv.Listen(&interrupter{sys: srv.sys, cancel: cancel}, true)
+
+	require.NoError(t, srv.Wait())
+}
+
+func TestWithSignals(t *testing.T) {
+	srv, _ := WithContext(t.Context(), WithSignals(syscall.SIGHUP), WithReloadSignals())
+
+	assert.Equal(t, []os.Signal{syscall.SIGHUP}, srv.opts.Signals)
+	assert.Empty(t, srv.opts.ReloadSignals)
+
+	srv, _ = WithContext(t.Context())
+	assert.Empty(t, srv.opts.ReloadSignals)
+}
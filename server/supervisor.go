@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/pkg/async"
+)
+
+// ErrRestartLimit is returned when a listener exceeded its restart budget.
+var ErrRestartLimit = errors.New("restart limit exceeded")
+
+// RestartPolicy is the policy to restart a supervised listener.
+type RestartPolicy int
+
+const (
+	// NoRestart is never restarting the listener,
+	// an error of the listener stops the server.
+	NoRestart RestartPolicy = iota
+	// Permanent is always restarting the listener.
+	Permanent
+	// Transient is restarting the listener if it returned an error.
+	Transient
+	// Temporary is never restarting the listener,
+	// an error of the listener does not stop the server.
+	Temporary
+)
+
+// String returns the name of the policy.
+func (p RestartPolicy) String() string {
+	switch p {
+	case Permanent:
+		return "permanent"
+	case Transient:
+		return "transient"
+	case Temporary:
+		return "temporary"
+	default:
+		return "none"
+	}
+}
+
+const (
+	// DefaultMaxRestarts is the default number of restarts allowed within the window.
+	DefaultMaxRestarts = 5
+	// DefaultRestartWindow is the default window to count the restarts in.
+	DefaultRestartWindow = time.Minute
+	// DefaultMinBackoff is the default backoff before the first restart.
+	DefaultMinBackoff = 100 * time.Millisecond
+	// DefaultMaxBackoff is the default maximum backoff between restarts.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// maxHistory is the number of restarts kept in the history of a listener.
+const maxHistory = 100
+
+// Supervision configures the supervision of a listener.
+type Supervision struct {
+	// Policy is the restart policy of the listener.
+	Policy RestartPolicy
+	// MaxRestarts is the number of restarts allowed within the window,
+	// before the listener stops the server.
+	MaxRestarts int
+	// Window is the time window to count the restarts in.
+	Window time.Duration
+	// MinBackoff is the backoff before the first restart,
+	// it doubles with each restart in the window.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum backoff between restarts.
+	MaxBackoff time.Duration
+}
+
+// DefaultSupervision returns the default supervision for the policy.
+func DefaultSupervision(policy RestartPolicy) Supervision {
+	return Supervision{
+		Policy:      policy,
+		MaxRestarts: DefaultMaxRestarts,
+		Window:      DefaultRestartWindow,
+		MinBackoff:  DefaultMinBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// withDefaults returns the supervision with the unset fields set to the defaults of the policy.
+func (s Supervision) withDefaults() Supervision {
+	d := DefaultSupervision(s.Policy)
+
+	if s.MaxRestarts <= 0 {
+		s.MaxRestarts = d.MaxRestarts
+	}
+
+	if s.Window <= 0 {
+		s.Window = d.Window
+	}
+
+	if s.MinBackoff <= 0 {
+		s.MinBackoff = d.MinBackoff
+	}
+
+	if s.MaxBackoff <= 0 {
+		s.MaxBackoff = max(d.MaxBackoff, s.MinBackoff)
+	}
+
+	return s
+}
+
+// backoff returns the backoff for the nth restart in the window.
+func (s Supervision) backoff(n int) time.Duration {
+	return async.Exponential(s.MinBackoff, s.MaxBackoff)(n, 0)
+}
+
+// Restart is a restart of a supervised listener.
+type Restart struct {
+	// Time is the time the listener terminated.
+	Time time.Time
+	// Err is the error the listener terminated with.
+	Err error
+}
+
+// RestartError is reporting the restart history of a supervised listener.
+type RestartError struct {
+	// Listener is the name of the listener.
+	Listener string
+	// Restarts are the restarts of the listener.
+	Restarts []Restart
+}
+
+// Error implements the error interface.
+func (e *RestartError) Error() string {
+	msg := fmt.Sprintf("server: %s restarted %d times", e.Listener, len(e.Restarts))
+
+	if len(e.Restarts) > 0 && e.Restarts[len(e.Restarts)-1].Err != nil {
+		msg = fmt.Sprintf("%s, last error: %s", msg, e.Restarts[len(e.Restarts)-1].Err)
+	}
+
+	return msg
+}
+
+// Unwrap implements the Unwrap method.
+func (e *RestartError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Restarts))
+	for _, r := range e.Restarts {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+
+	return errs
+}
+
+type history struct {
+	sync.Mutex
+	restarts []Restart
+}
+
+func (h *history) add(r Restart) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.restarts = append(h.restarts, r)
+	if len(h.restarts) > maxHistory {
+		h.restarts = h.restarts[len(h.restarts)-maxHistory:]
+	}
+}
+
+func (h *history) since(t time.Time) int {
+	h.Lock()
+	defer h.Unlock()
+
+	n := 0
+	for _, r := range h.restarts {
+		if r.Time.After(t) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (h *history) list() []Restart {
+	h.Lock()
+	defer h.Unlock()
+
+	return append([]Restart(nil), h.restarts...)
+}
+
+// instance is a single run of a listener and its routines.
+type instance struct {
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+	cancel  context.CancelFunc
+	onError func(error)
+}
+
+func (i *instance) fail(err error) {
+	i.errOnce.Do(func() {
+		i.err = err
+		i.cancel()
+		i.onError(err)
+	})
+}
+
+func (i *instance) wrap(f func() error) func() error {
+	i.wg.Add(1)
+
+	return func() error {
+		defer i.wg.Done()
+
+		if err := f(); err != nil {
+			i.fail(err)
+		}
+
+		return nil
+	}
+}
+
+func (i *instance) wait() error {
+	i.wg.Wait()
+	i.errOnce.Do(func() {
+		// noop, required to synchronise on the errOnce mutex.
+	})
+
+	return i.err
+}
+
+// supervise is running the listener and restarts it according to its supervision.
+func (s *server) supervise(l *listener) error {
+	sup := l.opts.Supervision
+
+	for {
+		ctx, cancel := context.WithCancel(s.ctx)
+
+		inst := &instance{cancel: cancel, onError: func(err error) {
+			s.emit(EventError, l, err)
+
+			if sup.Policy == NoRestart {
+				s.fail(err)
+			}
+		}}
+
+		runFn := func(f func() error) { s.run(l.name, inst.wrap(f)) }
+		readyFn := func() { s.signal(l) }
+
+		s.emit(EventStart, l, nil)
+
+		start := inst.wrap(l.Start(ctx, readyFn, runFn))
+		_ = start()
+
+		err := inst.wait()
+		cancel()
+
+		if err == nil {
+			s.signal(l)
+		}
+
+		if s.ctx.Err() != nil {
+			return nil
+		}
+
+		switch sup.Policy {
+		case Permanent:
+		case Transient:
+			if err == nil {
+				return nil
+			}
+		case Temporary:
+			if err != nil {
+				l.history.add(Restart{Time: time.Now(), Err: err})
+			}
+
+			return nil
+		case NoRestart:
+			return nil
+		}
+
+		now := time.Now()
+		n := l.history.since(now.Add(-sup.Window))
+		l.history.add(Restart{Time: now, Err: err})
+
+		if n >= sup.MaxRestarts {
+			return NewServerError(fmt.Errorf("%s: %w", l.name, ErrRestartLimit))
+		}
+
+		timer := time.NewTimer(sup.backoff(n + 1))
+
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errFlaky = errors.New("flaky")
+
+type flaky struct {
+	failures int32
+	attempts atomic.Int32
+	cancel   context.CancelFunc
+}
+
+func (f *flaky) Start(ctx context.Context, ready ReadyFunc, run RunFunc) func() error {
+	return func() error {
+		ready()
+
+		if f.attempts.Add(1) <= f.failures {
+			run(func() error { return errFlaky })
+			<-ctx.Done()
+
+			return nil
+		}
+
+		if f.cancel != nil {
+			f.cancel()
+		}
+
+		return nil
+	}
+}
+
+func testSupervision(policy RestartPolicy, maxRestarts int) Supervision {
+	return Supervision{
+		Policy:      policy,
+		MaxRestarts: maxRestarts,
+		Window:      time.Minute,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}
+}
+
+func TestSupervisionTransient(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx)
+
+	l := &flaky{failures: 2, cancel: cancel}
+	srv.ListenWith(l, WithName("flaky"), WithSupervision(testSupervision(Transient, 3)))
+
+	require.NoError(t, srv.Wait())
+	assert.Equal(t, int32(3), l.attempts.Load())
+
+	restarts := srv.Restarts()
+	require.Len(t, restarts, 1)
+	assert.Equal(t, "flaky", restarts[0].Listener)
+	assert.Len(t, restarts[0].Restarts, 2)
+}
+
+func TestSupervisionRestartLimit(t *testing.T) {
+	srv, _ := WithContext(t.Context())
+
+	l := &flaky{failures: 10}
+	srv.ListenWith(l, WithName("flaky"), WithSupervision(testSupervision(Permanent, 2)))
+
+	err := srv.Wait()
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrRestartLimit)
+	require.ErrorIs(t, err, errFlaky)
+
+	restartErr := &RestartError{}
+	require.ErrorAs(t, err, &restartErr)
+	assert.Equal(t, "flaky", restartErr.Listener)
+	assert.Len(t, restartErr.Restarts, 3)
+	assert.Equal(t, int32(3), l.attempts.Load())
+}
+
+func TestSupervisionTemporary(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx)
+
+	l := &flaky{failures: 1}
+	srv.ListenWith(l, WithSupervision(testSupervision(Temporary, 0)))
+	srv.ListenWith(&readiness{health: srv.Health(), cancel: cancel})
+
+	require.NoError(t, srv.Wait())
+	assert.Equal(t, int32(1), l.attempts.Load())
+}
+
+func TestSupervisionNoRestart(t *testing.T) {
+	srv, _ := WithContext(t.Context())
+
+	l := &flaky{failures: 1}
+	srv.ListenWith(l)
+
+	err := srv.Wait()
+	require.ErrorIs(t, err, errFlaky)
+	assert.Equal(t, int32(1), l.attempts.Load())
+}
+
+func TestSupervisionBackoff(t *testing.T) {
+	sup := Supervision{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, sup.backoff(1))
+	assert.Equal(t, 2*time.Second, sup.backoff(2))
+	assert.Equal(t, 4*time.Second, sup.backoff(3))
+	assert.Equal(t, 5*time.Second, sup.backoff(4))
+	assert.Equal(t, 5*time.Second, sup.backoff(10))
+}
+
+func TestWithSupervisionDefaults(t *testing.T) {
+	opts := new(ListenOpts)
+	opts.Configure(WithSupervision(Supervision{Policy: Permanent, MinBackoff: time.Second}))
+
+	assert.Equal(t, Supervision{
+		Policy:      Permanent,
+		MaxRestarts: DefaultMaxRestarts,
+		Window:      DefaultRestartWindow,
+		MinBackoff:  time.Second,
+		MaxBackoff:  DefaultMaxBackoff,
+	}, opts.Supervision)
+}
+
+func TestRestartPolicyString(t *testing.T) {
+	assert.Equal(t, "none", NoRestart.String())
+	assert.Equal(t, "permanent", Permanent.String())
+	assert.Equal(t, "transient", Transient.String())
+	assert.Equal(t, "temporary", Temporary.String())
+}
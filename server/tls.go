@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/pkg/logx"
+)
+
+// ErrNoCertificate is returned when no certificate is configured.
+var ErrNoCertificate = errors.New("no certificate")
+
+// DefaultCertReloadInterval is the default interval to check the certificate files for changes.
+const DefaultCertReloadInterval = 10 * time.Second
+
+// Certificates is loading a certificate and an optional client CA from files,
+// and is reloading them when the files on disk change.
+type Certificates struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	base     *tls.Config
+
+	mu      sync.RWMutex
+	config  *tls.Config
+	modTime time.Time
+}
+
+// NewCertificates returns new certificates loaded from the files.
+// The certificate of the base config is used if no certificate file is set.
+// Client certificates are required and verified if a client CA file is set.
+func NewCertificates(certFile, keyFile, caFile string, base *tls.Config) (*Certificates, error) {
+	if base == nil {
+		base = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	c := &Certificates{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		base:     base,
+	}
+
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Config returns the TLS config serving the current certificates.
+func (c *Certificates) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: c.getConfigForClient,
+	}
+}
+
+func (c *Certificates) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config, nil
+}
+
+// Reload is loading the certificates from the files.
+func (c *Certificates) Reload() error {
+	modTime, err := c.lastModified()
+	if err != nil {
+		return err
+	}
+
+	cfg := c.base.Clone()
+
+	if len(cfg.NextProtos) == 0 {
+		cfg.NextProtos = []string{"h2", "http/1.1"}
+	}
+
+	if c.certFile != "" || c.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+		if err != nil {
+			return NewServerError(fmt.Errorf("load certificate: %w", err))
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(cfg.Certificates) == 0 && cfg.GetCertificate == nil {
+		return NewServerError(ErrNoCertificate)
+	}
+
+	if c.caFile != "" {
+		pem, err := os.ReadFile(c.caFile)
+		if err != nil {
+			return NewServerError(fmt.Errorf("load client ca: %w", err))
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return NewServerError(fmt.Errorf("load client ca: no certificates in %s", c.caFile))
+		}
+
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.config = cfg
+	c.modTime = modTime
+
+	return nil
+}
+
+// Watch is reloading the certificates when the files change,
+// until the context is canceled.
+func (c *Certificates) Watch(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		changed, err := c.changed()
+		if err != nil {
+			logx.Errorw("check certificates", "error", err)
+			continue
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := c.Reload(); err != nil {
+			logx.Errorw("reload certificates", "error", err)
+			continue
+		}
+
+		logx.Infow("reloaded certificates", "cert", c.certFile, "ca", c.caFile)
+	}
+}
+
+func (c *Certificates) changed() (bool, error) {
+	modTime, err := c.lastModified()
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return modTime.After(c.modTime), nil
+}
+
+func (c *Certificates) lastModified() (time.Time, error) {
+	var modTime time.Time
+
+	for _, file := range []string{c.certFile, c.keyFile, c.caFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, NewServerError(err)
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
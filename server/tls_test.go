@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (ca *testCA) issue(t *testing.T, name string, serial int64) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func serialOf(t *testing.T, cfg *tls.Config) int64 {
+	t.Helper()
+
+	require.Len(t, cfg.Certificates, 1)
+
+	cert, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+
+	return cert.SerialNumber.Int64()
+}
+
+func TestCertificatesReload(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	cert, key := ca.issue(t, "server", 2)
+	past := time.Now().Add(-time.Minute)
+	writeFile(t, certFile, cert, past)
+	writeFile(t, keyFile, key, past)
+
+	certs, err := NewCertificates(certFile, keyFile, "", nil)
+	require.NoError(t, err)
+
+	cfg, err := certs.getConfigForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), serialOf(t, cfg))
+
+	changed, err := certs.changed()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	cert, key = ca.issue(t, "server", 3)
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go func() { _ = certs.Watch(ctx, time.Millisecond) }()
+
+	assert.Eventually(t, func() bool {
+		cfg, err := certs.getConfigForClient(nil)
+		return err == nil && serialOf(t, cfg) == 3
+	}, time.Second, time.Millisecond)
+}
+
+func TestCertificatesNoCertificate(t *testing.T) {
+	_, err := NewCertificates("", "", "", nil)
+	require.ErrorIs(t, err, ErrNoCertificate)
+}
+
+func TestDebugMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	cert, key := ca.issue(t, "server", 2)
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	writeFile(t, caFile, ca.pem, time.Now())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	srv, _ := WithContext(ctx, WithShutdownTimeout(time.Second))
+
+	d := NewDebug(WithAddr("127.0.0.1:0"), WithHealth(srv.Health()), WithTLS(certFile, keyFile), WithClientCA(caFile))
+	srv.Listen(d, true)
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Wait() }()
+
+	require.Eventually(t, srv.Health().Ready, time.Second, time.Millisecond)
+
+	d.mu.Lock()
+	url := "https://" + d.addr.String() + "/livez"
+	d.mu.Unlock()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req) //nolint:bodyclose
+	require.Error(t, err)
+
+	clientCert, clientKey := ca.issue(t, "client", 3)
+	pair, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, Certificates: []tls.Certificate{pair}, MinVersion: tls.VersionTLS12}}}
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, d.Reload(t.Context()))
+
+	cancel()
+	require.NoError(t, <-errs)
+}